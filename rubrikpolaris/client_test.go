@@ -0,0 +1,93 @@
+package rubrikpolaris
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client sending its GraphQL requests to a test
+// server running handler, with a static token.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Credentials {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &Credentials{
+		Host:    strings.TrimPrefix(server.URL, "http://"),
+		BaseURL: server.URL,
+		TokenSource: TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+			return &Token{
+				AccessToken: "token",
+				ExpiresAt:   time.Now().Add(time.Hour),
+			}, nil
+		}),
+	}
+}
+
+func TestReauthenticate(t *testing.T) {
+	tests := []struct {
+		name     string
+		rejected func(w http.ResponseWriter)
+	}{{
+		name: "Status",
+		rejected: func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusUnauthorized)
+		},
+	}, {
+		name: "ErrorType",
+		rejected: func(w http.ResponseWriter) {
+			w.Write([]byte(`{"errorType": "UNAUTHENTICATED", "message": "expired"}`))
+		},
+	}, {
+		name: "Errors",
+		rejected: func(w http.ResponseWriter) {
+			w.Write([]byte(`{"data": null, "errors": [{"message": "expired", "extensions": {"code": "UNAUTHENTICATED"}}]}`))
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokens := 0
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") == "Bearer token-1" {
+					test.rejected(w)
+					return
+				}
+				w.Write([]byte(`{"data": {"ok": true}}`))
+			})
+			c.TokenSource = TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+				tokens++
+				return &Token{
+					AccessToken: fmt.Sprintf("token-%d", tokens),
+					ExpiresAt:   time.Now().Add(time.Hour),
+				}, nil
+			})
+
+			if _, err := c.Query("query Test { ok }"); err != nil {
+				t.Fatal(err)
+			}
+			if tokens != 2 {
+				t.Fatalf("got %d token requests, want 2", tokens)
+			}
+		})
+	}
+}
+
+func TestReauthenticateRejectedAgain(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"errorType": "UNAUTHENTICATED", "message": "expired"}`))
+	})
+
+	_, err := c.Query("query Test { ok }")
+	var authErr *AuthError
+	if !errors.As(err, &authErr) || !IsUnauthorized(err) {
+		t.Fatalf("got %v, want an AuthError", err)
+	}
+}
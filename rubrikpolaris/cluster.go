@@ -1,74 +1,87 @@
 package rubrikpolaris
 
 import (
-	"github.com/mitchellh/mapstructure"
+	"context"
 )
 
 func (c *Credentials) GetCDMClusterIdByName(clusterNames []string, timeout ...int) ([]string, error) {
+	return c.GetCDMClusterIdByNameContext(context.Background(), clusterNames, timeout...)
+}
 
-	httpTimeout := httpTimeout(timeout)
+// GetCDMClusterIdByNameContext is like GetCDMClusterIdByName but takes a
+// context which cancels the underlying requests when done.
+func (c *Credentials) GetCDMClusterIdByNameContext(ctx context.Context, clusterNames []string, timeout ...int) (_ []string, err error) {
 
-	query := c.readQueryFile("CDMClusterIdByName.graphql")
+	ctx, span := c.startSpan(ctx, "GetCDMClusterIdByName")
+	defer func() { endSpan(span, err) }()
 
-	variables := map[string]interface{}{}
-	variables["clusterNames"] = clusterNames
+	httpTimeout := httpTimeout(timeout)
 
-	cdmClusters, err := c.QueryWithVariables(query, variables, httpTimeout)
-	if err != nil {
+	variables := &ClusterListFilterQueryVariables{ClusterNames: clusterNames}
+
+	cdmClusters, err := c.ClusterListFilterQueryContext(withPage(ctx, 1), variables, httpTimeout)
+	if err != nil && !IsPartialData(err) {
 		return nil, err
 	}
+	partialErr := joinPartialData(nil, err)
 
-	// Convert the API Response (map[string]interface{}) to a struct
-	var apiResponse ClusterIdByName
-	mapErr := mapstructure.Decode(cdmClusters, &apiResponse)
-	if mapErr != nil {
-		return nil, mapErr
-	}
+	operation := c.fileOperationName("CDMClusterIdByName.graphql")
+	pages := 1
+	c.logPage(ctx, operation, pages, len(cdmClusters.ClusterConnection.Edges),
+		cdmClusters.ClusterConnection.PageInfo.HasNextPage,
+		stringValue(cdmClusters.ClusterConnection.PageInfo.EndCursor))
+	c.metrics().ObservePage(operation)
 
 	var clusterIds []string
 
-	for _, value := range apiResponse.Data.ClusterConnection.Edges {
-		if contains(clusterNames, value.Node.NAME) {
+	for _, value := range cdmClusters.ClusterConnection.Edges {
+		if contains(clusterNames, value.Node.Name) {
 			clusterIds = append(clusterIds, value.Node.ID)
 		}
 
 	}
 
-	if apiResponse.Data.ClusterConnection.PageInfo.HasNextPage == true {
-		variables["after"] = apiResponse.Data.ClusterConnection.PageInfo.EndCursor
+	if cdmClusters.ClusterConnection.PageInfo.HasNextPage == true {
+		variables.After = cdmClusters.ClusterConnection.PageInfo.EndCursor
 
 		for {
 
-			cdmClustersPagination, err := c.QueryWithVariables(query, variables, httpTimeout)
-			if err != nil {
+			cdmClustersPagination, err := c.ClusterListFilterQueryContext(withPage(ctx, pages+1), variables, httpTimeout)
+			if err != nil && !IsPartialData(err) {
 				return nil, err
 			}
+			partialErr = joinPartialData(partialErr, err)
 
-			// Convert the API Response (map[string]interface{}) to a struct
-			var apiResponsePagination ClusterIdByName
-			mapErr := mapstructure.Decode(cdmClustersPagination, &apiResponsePagination)
-			if mapErr != nil {
-				return nil, mapErr
-			}
-
-			for _, value := range apiResponsePagination.Data.ClusterConnection.Edges {
+			for _, value := range cdmClustersPagination.ClusterConnection.Edges {
 
-				if contains(clusterNames, value.Node.NAME) {
+				if contains(clusterNames, value.Node.Name) {
 					clusterIds = append(clusterIds, value.Node.ID)
 				}
 
 			}
 
-			if apiResponsePagination.Data.ClusterConnection.PageInfo.HasNextPage == false {
+			pages++
+			c.logPage(ctx, operation, pages, len(cdmClustersPagination.ClusterConnection.Edges),
+				cdmClustersPagination.ClusterConnection.PageInfo.HasNextPage,
+				stringValue(cdmClustersPagination.ClusterConnection.PageInfo.EndCursor))
+			c.metrics().ObservePage(operation)
+
+			if cdmClustersPagination.ClusterConnection.PageInfo.HasNextPage == false {
 				break
 			}
 
-			variables["after"] = apiResponsePagination.Data.ClusterConnection.PageInfo.EndCursor
+			variables.After = cdmClustersPagination.ClusterConnection.PageInfo.EndCursor
 
 		}
 
 	}
 
+	c.logPagination(ctx, operation, pages, len(clusterIds))
+	span.SetAttributes(attrOperationName.String(operation), attrPages.Int(pages),
+		attrItems.Int(len(clusterIds)))
+	if partialErr != nil {
+		return clusterIds, partialErr
+	}
 	return clusterIds, nil
 
 }
@@ -0,0 +1,44 @@
+package rubrikpolaris
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetCDMClusterIdByNamePagination(t *testing.T) {
+	var afters []interface{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		afters = append(afters, req.Variables["after"])
+
+		if req.Variables["after"] == nil {
+			w.Write([]byte(`{"data": {"clusterConnection": {
+				"edges": [{"node": {"id": "id-1", "name": "a"}}, {"node": {"id": "id-2", "name": "x"}}],
+				"pageInfo": {"endCursor": "cursor-1", "hasNextPage": true}
+			}}}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"clusterConnection": {
+			"edges": [{"node": {"id": "id-3", "name": "b"}}],
+			"pageInfo": {"endCursor": null, "hasNextPage": false}
+		}}}`))
+	})
+
+	ids, err := c.GetCDMClusterIdByName([]string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"id-1", "id-3"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("got %v, want %v", ids, want)
+	}
+	if want := []interface{}{nil, "cursor-1"}; !reflect.DeepEqual(afters, want) {
+		t.Fatalf("got after variables %v, want %v", afters, want)
+	}
+}
@@ -0,0 +1,149 @@
+package rubrikpolaris
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+)
+
+// Do sends the GraphQL query, or mutation, with the given variables using the
+// client c, and decodes the data field of the response into a T with
+// encoding/json. T is usually a struct mirroring the selection set of the
+// query, e.g.:
+//
+//	type clusters struct {
+//		ClusterConnection struct {
+//			Nodes []struct {
+//				ID   string `json:"id"`
+//				Name string `json:"name"`
+//			} `json:"nodes"`
+//		} `json:"clusterConnection"`
+//	}
+//
+//	data, err := rubrikpolaris.Do[clusters](ctx, client, query, nil)
+//
+// Tokens, operation names, retries and errors are handled as for
+// QueryWithVariables. When the response holds both data and errors, the
+// decoded data is returned together with a *PartialDataError.
+func Do[T any](
+	ctx context.Context,
+	c *Credentials,
+	query string,
+	variables map[string]interface{},
+	timeout ...int) (T, error) {
+
+	return DoRequest[T](ctx, c, &GraphQLRequest{
+		Query:     query,
+		Variables: variables,
+	}, timeout...)
+}
+
+// DoRequest is like Do but sends a GraphQLRequest, to select one of several
+// operations, set extensions or flag a mutation.
+func DoRequest[T any](
+	ctx context.Context,
+	c *Credentials,
+	req *GraphQLRequest,
+	timeout ...int) (T, error) {
+
+	var data T
+	err := c.graphQL(ctx, req, httpTimeout(timeout), func(body []byte) error {
+		return decodeData(body, &data)
+	})
+	return data, err
+}
+
+// decodeData decodes the data field of a GraphQL response body into out, a
+// pointer. Errors are reported like decodeAPIResponse does.
+func decodeData(body []byte, out interface{}) error {
+	respErr := responseError(body)
+	if respErr != nil && !IsPartialData(respErr) {
+		return respErr
+	}
+
+	var response struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return &DecodeError{Err: err}
+	}
+
+	if len(response.Data) > 0 && string(response.Data) != "null" {
+		if err := json.Unmarshal(response.Data, out); err != nil {
+			return &DecodeError{
+				Type: typeName(out),
+				Err:  err,
+			}
+		}
+	}
+
+	return respErr
+}
+
+// typeName returns the name of the type pointed to by out, or its kind for
+// unnamed types.
+func typeName(out interface{}) string {
+	t := reflect.TypeOf(out).Elem()
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return t.Kind().String()
+}
+
+// doOperation sends the operation of the embedded GraphQL file with the given
+// variables, a struct generated from the operation or nil, and decodes the
+// data of the response into a T. A response without data is returned as a
+// *DecodeError, so that it is not mistaken for an empty result. It is used by
+// the generated methods of operations_gen.go.
+func doOperation[T any](
+	ctx context.Context,
+	c *Credentials,
+	file string,
+	variables interface{},
+	mutation bool,
+	timeout ...int) (*T, error) {
+
+	vars, err := variablesMap(variables)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := DoRequest[*T](ctx, c, &GraphQLRequest{
+		Query:     c.readQueryFile(file),
+		Variables: vars,
+		Mutation:  mutation,
+	}, timeout...)
+	if err != nil && !IsPartialData(err) {
+		return nil, err
+	}
+	if data == nil {
+		return nil, &DecodeError{
+			Type: typeName(new(T)),
+			Err:  errors.New("the response holds no data"),
+		}
+	}
+	return data, err
+}
+
+// variablesMap converts the variables struct of an operation into the map
+// sent to the API, keeping the numbers as they are.
+func variablesMap(variables interface{}) (map[string]interface{}, error) {
+	if variables == nil || reflect.ValueOf(variables).IsNil() {
+		return nil, nil
+	}
+
+	buf, err := json.Marshal(variables)
+	if err != nil {
+		return nil, err
+	}
+
+	var vars map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(buf))
+	decoder.UseNumber()
+	if err := decoder.Decode(&vars); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
@@ -0,0 +1,122 @@
+package rubrikpolaris
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestDecodeConsistency checks that Do and QueryWithVariables classify the
+// same response bodies the same way.
+func TestDecodeConsistency(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+
+		// err is the expected error type, empty for none.
+		err string
+		msg string
+	}{{
+		name: "Data",
+		body: `{"data": {"value": 1}}`,
+	}, {
+		name: "Errors",
+		body: `{"data": null, "errors": [{"message": "failed", "extensions": {"code": "NOT_FOUND"}}]}`,
+		err:  "rubrikpolaris.GraphQLErrors",
+		msg:  "failed",
+	}, {
+		name: "PartialData",
+		body: `{"data": {"value": 1}, "errors": [{"message": "failed", "path": ["other"]}]}`,
+		err:  "*rubrikpolaris.PartialDataError",
+		msg:  "the API returned partial data: failed (path: other)",
+	}, {
+		name: "ErrorsBeforeErrorType",
+		body: `{"errors": [{"message": "failed"}], "errorType": "INTERNAL", "message": "internal"}`,
+		err:  "rubrikpolaris.GraphQLErrors",
+		msg:  "failed",
+	}, {
+		name: "ErrorType",
+		body: `{"errorType": "UNAUTHENTICATED", "message": "expired"}`,
+		err:  "*rubrikpolaris.GraphQLError",
+		msg:  "expired",
+	}, {
+		name: "ErrorTypeWithoutMessage",
+		body: `{"errorType": "INTERNAL"}`,
+		err:  "*rubrikpolaris.GraphQLError",
+		msg:  "<nil>",
+	}, {
+		name: "Message",
+		body: `{"message": "failed"}`,
+		err:  "*rubrikpolaris.GraphQLError",
+		msg:  "failed",
+	}, {
+		name: "BootstrapMessage",
+		body: `{"message": "in progress", "setupEncryptionAtRest": true}`,
+	}, {
+		name: "InvalidJSON",
+		body: `{"data": `,
+		err:  "*rubrikpolaris.DecodeError",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, apiErr := decodeAPIResponse([]byte(test.body))
+			var data map[string]interface{}
+			dataErr := decodeData([]byte(test.body), &data)
+
+			for path, err := range map[string]error{
+				"decodeAPIResponse": apiErr,
+				"decodeData":        dataErr,
+			} {
+				if test.err == "" {
+					if err != nil {
+						t.Errorf("%s: unexpected error: %v", path, err)
+					}
+					continue
+				}
+				if got := fmt.Sprintf("%T", err); got != test.err {
+					t.Errorf("%s: got error %s (%v), want %s", path, got, err, test.err)
+					continue
+				}
+				if test.msg != "" && err.Error() != test.msg {
+					t.Errorf("%s: got message %q, want %q", path, err, test.msg)
+				}
+			}
+
+			if test.name == "Errors" && !IsNotFound(apiErr) {
+				t.Errorf("the code of the GraphQL errors is lost: %v", apiErr)
+			}
+			if IsPartialData(dataErr) && data["value"] != float64(1) {
+				t.Errorf("the partial data is not decoded: %v", data)
+			}
+		})
+	}
+}
+
+func TestDecodeDataType(t *testing.T) {
+	var data struct {
+		Value int `json:"value"`
+	}
+	err := decodeData([]byte(`{"data": {"value": "one"}}`), &data)
+
+	var decodeErr *DecodeError
+	if !errors.As(err, &decodeErr) || decodeErr.Type != "struct" {
+		t.Fatalf("got %v, want a DecodeError for struct", err)
+	}
+}
+
+func TestOperationWithoutData(t *testing.T) {
+	for _, body := range []string{`{}`, `{"data": null}`} {
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		})
+
+		resp, err := c.RadarEnabledClusters()
+		var decodeErr *DecodeError
+		if !errors.As(err, &decodeErr) || decodeErr.Type != "RadarEnabledClustersResponse" {
+			t.Errorf("%s: got %v, %v, want a DecodeError for RadarEnabledClustersResponse",
+				body, resp, err)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package rubrikpolaris
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+)
+
+// maxBodyExcerpt is the maximum number of bytes of a response body kept in
+// an HTTPError.
+const maxBodyExcerpt = 512
+
+// GraphQLErrorLocation is a position in the GraphQL document sent to the API.
+type GraphQLErrorLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLError is an error reported by the API in the body of a response,
+// either an entry of the GraphQL errors array or an errorType and message.
+type GraphQLError struct {
+	Message string `json:"message"`
+
+	// Path is the path of the response field which failed, made of field
+	// names and list indices.
+	Path []interface{} `json:"path"`
+
+	// Locations are the positions in the GraphQL document the error
+	// relates to.
+	Locations []GraphQLErrorLocation `json:"locations"`
+
+	// Extensions holds additional information about the error.
+	Extensions map[string]interface{} `json:"extensions"`
+
+	// Code is the error code, read from the code extension or from the
+	// errorType field of the response.
+	Code string `json:"-"`
+}
+
+func (e *GraphQLError) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+
+	path := make([]string, len(e.Path))
+	for i, segment := range e.Path {
+		path[i] = fmt.Sprint(segment)
+	}
+	return fmt.Sprintf("%s (path: %s)", e.Message, strings.Join(path, "."))
+}
+
+// GraphQLErrors holds the entries of the errors array of a GraphQL response.
+// Each entry can be reached with errors.As.
+type GraphQLErrors []*GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e GraphQLErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// parseGraphQLErrors returns the entries of the errors array of a GraphQL
+// response body, nil when there are none.
+func parseGraphQLErrors(body []byte) (GraphQLErrors, error) {
+	var response struct {
+		Errors GraphQLErrors `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	for _, err := range response.Errors {
+		if code, ok := err.Extensions["code"]; ok {
+			err.Code = fmt.Sprint(code)
+		}
+	}
+	return response.Errors, nil
+}
+
+// responseError returns the error reported in the body of a response of the
+// API, checked in the following order:
+//
+//   - the GraphQL errors array, as GraphQLErrors when there is no data and
+//     as a PartialDataError when there is,
+//   - an errorType field, as a GraphQLError with the errorType as code,
+//   - a message field, as a GraphQLError, except for the bootstrap
+//     responses holding setupEncryptionAtRest.
+//
+// A DecodeError is returned when the body is not valid JSON, and nil when it
+// is not a JSON object.
+func responseError(body []byte) error {
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(body, &response); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil
+		}
+		return &DecodeError{Err: err}
+	}
+
+	// The errors of a GraphQL response are returned along with its data,
+	// when there is some
+	if errs, _ := parseGraphQLErrors(body); len(errs) > 0 {
+		if data, ok := response["data"]; !ok || string(data) == "null" {
+			return errs
+		}
+		return &PartialDataError{Errors: errs}
+	}
+
+	if errorType, ok := response["errorType"]; ok {
+		return &GraphQLError{
+			Message: rawString(response["message"]),
+			Code:    rawString(errorType),
+		}
+	}
+
+	if message, ok := response["message"]; ok {
+		// Add exception for bootstrap
+		if _, ok := response["setupEncryptionAtRest"]; ok {
+			return nil
+		}
+		return &GraphQLError{Message: rawString(message)}
+	}
+
+	return nil
+}
+
+// rawString returns the JSON value raw formatted like fmt.Sprint formats the
+// decoded value.
+func rawString(raw json.RawMessage) string {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "<nil>"
+	}
+	return fmt.Sprint(value)
+}
+
+// PartialDataError is returned, together with the data, when a GraphQL
+// response holds both data and errors. Callers which accept partial results
+// can test for it with IsPartialData.
+type PartialDataError struct {
+	Errors GraphQLErrors
+}
+
+func (e *PartialDataError) Error() string {
+	return fmt.Sprintf("the API returned partial data: %s", e.Errors)
+}
+
+func (e *PartialDataError) Unwrap() error {
+	return e.Errors
+}
+
+// IsPartialData returns true when err is a PartialDataError, meaning that
+// the data returned along with it is usable but incomplete.
+func IsPartialData(err error) bool {
+	var partialErr *PartialDataError
+	return errors.As(err, &partialErr)
+}
+
+// joinPartialData merges the errors of the partial pages of a paginated
+// query. err is nil or a PartialDataError.
+func joinPartialData(partialErr *PartialDataError, err error) *PartialDataError {
+	var pageErr *PartialDataError
+	if !errors.As(err, &pageErr) {
+		return partialErr
+	}
+	if partialErr == nil {
+		return &PartialDataError{Errors: pageErr.Errors}
+	}
+	errs := append(GraphQLErrors{}, partialErr.Errors...)
+	return &PartialDataError{Errors: append(errs, pageErr.Errors...)}
+}
+
+// HTTPError is returned when the API responds with an unsuccessful HTTP
+// status.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+
+	// Body is an excerpt of the response body.
+	Body string
+
+	// RequestID is the value of the X-Request-Id response header, if any.
+	RequestID string
+
+	// RetryAfter is the delay requested by the Retry-After response header,
+	// zero when there is none.
+	RetryAfter time.Duration
+}
+
+func (e *HTTPError) Error() string {
+	if e.Body == "" {
+		return e.Status
+	}
+	return fmt.Sprintf("%s: %s", e.Status, e.Body)
+}
+
+// newHTTPError returns the HTTPError of the given response and body.
+func newHTTPError(resp *http.Response, body []byte) *HTTPError {
+	excerpt := strings.TrimSpace(string(body))
+	if len(excerpt) > maxBodyExcerpt {
+		cut := maxBodyExcerpt
+		for cut > 0 && !utf8.RuneStart(excerpt[cut]) {
+			cut--
+		}
+		excerpt = excerpt[:cut] + "..."
+	}
+
+	return &HTTPError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       excerpt,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+}
+
+// AuthError is returned when the client fails to authenticate, either
+// because a token could not be obtained or because the API rejected it.
+type AuthError struct {
+	Err error
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("authentication failed: %s", e.Err)
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError is returned when a response of the API cannot be decoded.
+type DecodeError struct {
+	// Type is the name of the type the response was decoded into, empty
+	// when the response is not valid JSON.
+	Type string
+
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("failed to decode the API response: %s", e.Err)
+	}
+	return fmt.Sprintf("failed to decode the API response into %s: %s",
+		e.Type, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetryable returns true when err is a transient failure worth retrying:
+// an HTTPError with status 429, 502, 503 or 504, a connection reset or
+// refused, or a network timeout.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return isTransientStatus(httpErr.StatusCode)
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IsUnauthorized returns true when err is an AuthError, an HTTPError with
+// status 401 or a GraphQLError with an UNAUTHENTICATED or UNAUTHORIZED code.
+func IsUnauthorized(err error) bool {
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		return true
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusUnauthorized {
+		return true
+	}
+
+	return hasGraphQLCode(err, "UNAUTHENTICATED", "UNAUTHORIZED")
+}
+
+// IsNotFound returns true when err is an HTTPError with status 404 or a
+// GraphQLError with a NOT_FOUND code.
+func IsNotFound(err error) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		return true
+	}
+
+	return hasGraphQLCode(err, "NOT_FOUND")
+}
+
+// hasGraphQLCode returns true when err is a GraphQLError with one of the
+// given codes, compared case insensitively.
+func hasGraphQLCode(err error, codes ...string) bool {
+	var graphQLErr *GraphQLError
+	if !errors.As(err, &graphQLErr) {
+		return false
+	}
+
+	for _, code := range codes {
+		if strings.EqualFold(graphQLErr.Code, code) {
+			return true
+		}
+	}
+	return false
+}
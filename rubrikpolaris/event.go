@@ -1,9 +1,8 @@
 package rubrikpolaris
 
 import (
+	"context"
 	"time"
-
-	"github.com/mitchellh/mapstructure"
 )
 
 const (
@@ -13,83 +12,118 @@ const (
 )
 
 func (c *Credentials) GetAllEvents(secondsTimeRange int, timeout ...int) (*AllEvents, error) {
+	return c.GetAllEventsContext(context.Background(), secondsTimeRange, timeout...)
+}
 
-	httpTimeout := httpTimeout(timeout)
+// GetAllEventsContext is like GetAllEvents but takes a context which cancels
+// the underlying requests when done.
+func (c *Credentials) GetAllEventsContext(ctx context.Context, secondsTimeRange int, timeout ...int) (_ *AllEvents, err error) {
 
-	query := c.readQueryFile("AllEventsPerTimePeriod.graphql")
+	ctx, span := c.startSpan(ctx, "GetAllEvents")
+	defer func() { endSpan(span, err) }()
+
+	httpTimeout := httpTimeout(timeout)
 
-	variables := map[string]interface{}{}
-	variables["timeAgo"] = time.Now().Add(time.Duration(secondsTimeRange*-1) * time.Second).UTC().Format(time.RFC3339)
+	timeAgo := time.Now().Add(time.Duration(secondsTimeRange*-1) * time.Second).UTC().Format(time.RFC3339)
 
-	events, err := c.QueryWithVariables(query, variables, httpTimeout)
-	if err != nil {
+	events, err := c.AllEventsPerTimePeriodContext(ctx, &AllEventsPerTimePeriodVariables{
+		TimeAgo: &timeAgo,
+	}, httpTimeout)
+	if err != nil && !IsPartialData(err) {
 		return nil, err
 	}
 
-	// Convert the API Response (map[string]interface{}) to a struct
+	operation := c.fileOperationName("AllEventsPerTimePeriod.graphql")
+	c.metrics().ObservePage(operation)
+	c.metrics().ObserveEvents(operation, len(events.ActivitySeriesConnection.Edges))
 	var apiResponse AllEvents
-	mapErr := mapstructure.Decode(events, &apiResponse)
-	if mapErr != nil {
-		return nil, mapErr
+	if err := convertResponse(events, &apiResponse.Data); err != nil {
+		return nil, err
 	}
-	return &apiResponse, nil
+	return &apiResponse, err
 
 }
 
 func (c *Credentials) GetAllAuditLog(timeAgo string, timeout ...int) (*AllAuditLog, error) {
+	return c.GetAllAuditLogContext(context.Background(), timeAgo, timeout...)
+}
 
-	httpTimeout := httpTimeout(timeout)
+// GetAllAuditLogContext is like GetAllAuditLog but takes a context which
+// cancels the underlying requests when done.
+func (c *Credentials) GetAllAuditLogContext(ctx context.Context, timeAgo string, timeout ...int) (_ *AllAuditLog, err error) {
 
-	query := c.readQueryFile("AllAuditLogPerTimePeriod.graphql")
+	ctx, span := c.startSpan(ctx, "GetAllAuditLog")
+	defer func() { endSpan(span, err) }()
 
-	variables := map[string]interface{}{}
-	variables["timeAgo"] = timeAgo
+	httpTimeout := httpTimeout(timeout)
 
-	eventLog, err := c.QueryWithVariables(query, variables, httpTimeout)
-	if err != nil {
+	eventLog, err := c.AllAuditLogPerTimePeriodContext(ctx, &AllAuditLogPerTimePeriodVariables{
+		TimeAgo: &timeAgo,
+	}, httpTimeout)
+	if err != nil && !IsPartialData(err) {
 		return nil, err
 	}
 
-	// Convert the API Response (map[string]interface{}) to a struct
+	operation := c.fileOperationName("AllAuditLogPerTimePeriod.graphql")
+	c.metrics().ObservePage(operation)
+	c.metrics().ObserveEvents(operation, len(eventLog.UserAuditConnection.Edges))
 	var apiResponse AllAuditLog
-	mapErr := mapstructure.Decode(eventLog, &apiResponse)
-	if mapErr != nil {
-		return nil, mapErr
+	if err := convertResponse(eventLog, &apiResponse.Data); err != nil {
+		return nil, err
 	}
-	return &apiResponse, nil
+	return &apiResponse, err
 
 }
 
 func (c *Credentials) GetEventDetails(activitySeriesID, clusterUUID string, timeout ...int) (*EventSeriesDetail, error) {
+	return c.GetEventDetailsContext(context.Background(), activitySeriesID, clusterUUID, timeout...)
+}
 
-	httpTimeout := httpTimeout(timeout)
+// GetEventDetailsContext is like GetEventDetails but takes a context which
+// cancels the underlying requests when done.
+func (c *Credentials) GetEventDetailsContext(ctx context.Context, activitySeriesID, clusterUUID string, timeout ...int) (_ *EventSeriesDetail, err error) {
 
-	query := c.readQueryFile("EventDetails.graphql")
+	ctx, span := c.startSpan(ctx, "GetEventDetails")
+	defer func() { endSpan(span, err) }()
 
-	variables := map[string]interface{}{}
-	variables["activitySeriesId"] = activitySeriesID
-	variables["clusterUuid"] = clusterUUID
+	httpTimeout := httpTimeout(timeout)
 
-	eventDetail, err := c.QueryWithVariables(query, variables, httpTimeout)
-	if err != nil {
+	eventDetail, err := c.EventDetailsContext(ctx, &EventDetailsVariables{
+		ActivitySeriesID: activitySeriesID,
+		ClusterUUID:      clusterUUID,
+	}, httpTimeout)
+	if err != nil && !IsPartialData(err) {
 		return nil, err
 	}
-
-	// Convert the API Response (map[string]interface{}) to a struct
 	var apiResponse EventSeriesDetail
-	mapErr := mapstructure.Decode(eventDetail, &apiResponse)
-	if mapErr != nil {
-		return nil, mapErr
+	if err := convertResponse(eventDetail, &apiResponse.Data); err != nil {
+		return nil, err
 	}
-	return &apiResponse, nil
+	return &apiResponse, err
 
 }
 
 func (c *Credentials) GetAllPolarisEvents(timeAgo string, timeout ...int) (*PolarisEvents, error) {
-	return c.GetAllRscEventsForCluster(timeAgo, "00000000-0000-0000-0000-000000000000", timeout...)
+	return c.GetAllPolarisEventsContext(context.Background(), timeAgo, timeout...)
+}
+
+// GetAllPolarisEventsContext is like GetAllPolarisEvents but takes a context
+// which cancels the underlying requests when done.
+func (c *Credentials) GetAllPolarisEventsContext(ctx context.Context, timeAgo string, timeout ...int) (*PolarisEvents, error) {
+	return c.GetAllRscEventsForClusterContext(ctx, timeAgo, "00000000-0000-0000-0000-000000000000", timeout...)
 }
 
 func (c *Credentials) GetAllRscEventsForCluster(timeAgo string, clusterId string, timeout ...int) (*PolarisEvents, error) {
+	return c.GetAllRscEventsForClusterContext(context.Background(), timeAgo, clusterId, timeout...)
+}
+
+// GetAllRscEventsForClusterContext is like GetAllRscEventsForCluster but takes
+// a context which cancels the underlying requests, and the waits between
+// pages, when done.
+func (c *Credentials) GetAllRscEventsForClusterContext(ctx context.Context, timeAgo string, clusterId string, timeout ...int) (_ *PolarisEvents, err error) {
+
+	ctx, span := c.startSpan(ctx, "GetAllRscEventsForCluster")
+	defer func() { endSpan(span, err) }()
 
 	httpTimeout := httpTimeout(timeout)
 
@@ -97,62 +131,72 @@ func (c *Credentials) GetAllRscEventsForCluster(timeAgo string, clusterId string
 		httpTimeout = 300
 	}
 
-	query := c.readQueryFile("AllPolarisEventPerTimePeriod.graphql")
-
-	variables := map[string]interface{}{}
-	variables["timeAgo"] = timeAgo
-	variables["clusterId"] = clusterId
+	variables := &AllPolarisEventPerTimePeriodVariables{
+		TimeAgo:   &timeAgo,
+		ClusterID: &clusterId,
+	}
 
-	eventDetail, err := c.QueryWithVariables(query, variables, httpTimeout)
-	if err != nil {
+	eventDetail, err := c.AllPolarisEventPerTimePeriodContext(withPage(ctx, 1), variables, httpTimeout)
+	if err != nil && !IsPartialData(err) {
 		return nil, err
 	}
+	partialErr := joinPartialData(nil, err)
+	events := eventDetail.ActivitySeriesConnection.Edges
 
-	// Convert the API Response (map[string]interface{}) to a struct
-	var apiResponse PolarisEvents
-	mapErr := mapstructure.Decode(eventDetail, &apiResponse)
-	if mapErr != nil {
-		return nil, mapErr
-	}
+	operation := c.fileOperationName("AllPolarisEventPerTimePeriod.graphql")
+	pages := 1
+	c.logPage(ctx, operation, pages, len(eventDetail.ActivitySeriesConnection.Edges),
+		eventDetail.ActivitySeriesConnection.PageInfo.HasNextPage,
+		stringValue(eventDetail.ActivitySeriesConnection.PageInfo.EndCursor))
+	c.metrics().ObservePage(operation)
 
-	var additionalData []PolarisEventsEdge
-	if apiResponse.Data.ActivitySeriesConnection.PageInfo.HasNextPage == true {
+	if eventDetail.ActivitySeriesConnection.PageInfo.HasNextPage == true {
 
-		variables["after"] = apiResponse.Data.ActivitySeriesConnection.PageInfo.EndCursor
+		variables.After = eventDetail.ActivitySeriesConnection.PageInfo.EndCursor
 
 		for {
 
-			eventDetailPagination, err := c.QueryWithVariables(query, variables, httpTimeout)
-			if err != nil {
+			eventDetailPagination, err := c.AllPolarisEventPerTimePeriodContext(withPage(ctx, pages+1), variables, httpTimeout)
+			if err != nil && !IsPartialData(err) {
 				return nil, err
 			}
+			partialErr = joinPartialData(partialErr, err)
 
-			// Convert the API Response (map[string]interface{}) to a struct
-			var apiResponsePagination PolarisEvents
-			mapErr := mapstructure.Decode(eventDetailPagination, &apiResponsePagination)
-			if mapErr != nil {
-				return nil, mapErr
-			}
+			events = append(events, eventDetailPagination.ActivitySeriesConnection.Edges...)
 
-			for _, data := range apiResponsePagination.Data.ActivitySeriesConnection.Edges {
-				additionalData = append(additionalData, data)
-			}
+			pages++
+			c.logPage(ctx, operation, pages, len(eventDetailPagination.ActivitySeriesConnection.Edges),
+				eventDetailPagination.ActivitySeriesConnection.PageInfo.HasNextPage,
+				stringValue(eventDetailPagination.ActivitySeriesConnection.PageInfo.EndCursor))
+			c.metrics().ObservePage(operation)
 
-			if apiResponsePagination.Data.ActivitySeriesConnection.PageInfo.HasNextPage == false {
+			if eventDetailPagination.ActivitySeriesConnection.PageInfo.HasNextPage == false {
 				break
 			}
 
-			variables["after"] = apiResponsePagination.Data.ActivitySeriesConnection.PageInfo.EndCursor
+			variables.After = eventDetailPagination.ActivitySeriesConnection.PageInfo.EndCursor
 
 			// Add some sleep before successive activitySeriesConnection queries to ease load on the database
-			time.Sleep(time.Duration(successiveEventQueryWaitPeriod) * time.Second)
+			if err := sleepContext(ctx, time.Duration(successiveEventQueryWaitPeriod)*time.Second); err != nil {
+				return nil, err
+			}
 
 		}
 
-		for _, data := range additionalData {
-			apiResponse.Data.ActivitySeriesConnection.Edges = append(apiResponse.Data.ActivitySeriesConnection.Edges, data)
-		}
+	}
+
+	eventDetail.ActivitySeriesConnection.Edges = events
+	var apiResponse PolarisEvents
+	if err := convertResponse(eventDetail, &apiResponse.Data); err != nil {
+		return nil, err
+	}
 
+	c.logPagination(ctx, operation, pages, len(events))
+	c.metrics().ObserveEvents(operation, len(events))
+	span.SetAttributes(attrOperationName.String(operation), attrPages.Int(pages),
+		attrItems.Int(len(events)))
+	if partialErr != nil {
+		return &apiResponse, partialErr
 	}
 	return &apiResponse, nil
 
@@ -0,0 +1,111 @@
+package rubrikpolaris
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetAllRscEventsForCluster(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"activitySeriesConnection": {
+			"edges": [{"node": {
+				"id": 12, "activitySeriesId": "series-1", "progress": null,
+				"cluster": {"id": "cluster-1", "name": "a"},
+				"activityConnection": {"nodes": [{"id": "1", "message": "done"}]}
+			}}, {"node": {"id": 13, "progress": "50%", "cluster": null}}],
+			"pageInfo": {"endCursor": null, "hasNextPage": false}
+		}}}`))
+	})
+
+	events, err := c.GetAllRscEventsForCluster("2024-01-01T00:00:00Z", "cluster-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edges := events.Data.ActivitySeriesConnection.Edges
+	if len(edges) != 2 {
+		t.Fatalf("got %d events, want 2", len(edges))
+	}
+	if node := edges[0].Node; node.ID != 12 || node.ActivitySeriesID != "series-1" ||
+		node.Progress != "" || node.Cluster.Name != "a" ||
+		node.ActivityConnection.Nodes[0].Message != "done" {
+		t.Errorf("the first event is not decoded: %+v", node)
+	}
+	if node := edges[1].Node; node.ID != 13 || node.Progress != "50%" || node.Cluster.ID != "" {
+		t.Errorf("the second event is not decoded: %+v", node)
+	}
+}
+
+// testMetrics records the pages and events observed by a client, and the
+// operations of its requests.
+type testMetrics struct {
+	noMetrics
+
+	mu       sync.Mutex
+	requests map[string]int
+	pages    map[string]int
+	events   map[string]int
+}
+
+func (m *testMetrics) ObserveRequest(callType, operation string, statusCode int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[operation]++
+}
+
+func (m *testMetrics) ObservePage(operation string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pages[operation]++
+}
+
+func (m *testMetrics) ObserveEvents(operation string, count int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events[operation] += count
+}
+
+func TestEventMetrics(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {
+			"activitySeriesConnection": {"edges": [{"node": {"id": 1}}, {"node": {"id": 2}}]},
+			"userAuditConnection": {"edges": [{"node": {"id": "1"}}, {"node": {"id": "2"}}]}
+		}}`))
+	})
+	c.OperationName = "Test"
+	metrics := &testMetrics{
+		requests: map[string]int{},
+		pages:    map[string]int{},
+		events:   map[string]int{},
+	}
+	c.Metrics = metrics
+
+	if _, err := c.GetAllEvents(60); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetAllAuditLog("2024-01-01T00:00:00Z"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetRadarEvents("2024-01-01T00:00:00Z"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetRadarAndSonarEvents("2024-01-01T00:00:00Z"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, operation := range []string{
+		"TestAllEventsPerTimePeriod",
+		"TestAllAuditLogPerTimePeriod",
+		"TestRadarEventsPerTimePeriod",
+		"TestRadarSonarEventsPerTimePeriod",
+	} {
+		if metrics.requests[operation] != 1 || metrics.pages[operation] != 1 ||
+			metrics.events[operation] != 2 {
+			t.Errorf("%s: got %d requests, %d pages and %d events, want 1, 1 and 2",
+				operation, metrics.requests[operation], metrics.pages[operation],
+				metrics.events[operation])
+		}
+	}
+}
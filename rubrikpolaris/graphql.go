@@ -0,0 +1,172 @@
+package rubrikpolaris
+
+import (
+	"fmt"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/lexer"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// selectOperation parses the GraphQL document and returns its operation
+// named name. When name is empty the document must hold a single operation,
+// which is returned.
+func selectOperation(document, name string) (*ast.OperationDefinition, error) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: document})
+	if err != nil {
+		return nil, fmt.Errorf("invalid GraphQL document: %w", err)
+	}
+
+	if name != "" {
+		operation := doc.Operations.ForName(name)
+		if operation == nil {
+			return nil, fmt.Errorf(
+				"the GraphQL document has no operation named %q", name)
+		}
+		return operation, nil
+	}
+
+	switch len(doc.Operations) {
+	case 0:
+		return nil, fmt.Errorf("the GraphQL document has no operation")
+	case 1:
+		return doc.Operations[0], nil
+	default:
+		return nil, fmt.Errorf(
+			"the GraphQL document has %d operations, an operation name is required",
+			len(doc.Operations))
+	}
+}
+
+// parseOperationName returns the name of the single operation of the GraphQL
+// document query. An empty string is returned for an anonymous operation and
+// for an invalid document.
+func parseOperationName(query string) string {
+	operation, err := selectOperation(query, "")
+	if err != nil {
+		return ""
+	}
+	return operation.Name
+}
+
+// renameOperation returns the GraphQL document with operation, one of its
+// operations, renamed newName. An anonymous operation is given the name and
+// the rest of the document is left untouched.
+func renameOperation(
+	document string,
+	operation *ast.OperationDefinition,
+	newName string) (string, error) {
+
+	if newName == "" || newName == operation.Name {
+		return document, nil
+	}
+
+	// Token positions are counted in runes
+	runes := []rune(document)
+	replace := func(start, end int, s string) string {
+		return string(runes[:start]) + s + string(runes[end:])
+	}
+
+	lex := lexer.New(&ast.Source{Input: document})
+	for {
+		token, err := lex.ReadToken()
+		if err != nil {
+			return "", fmt.Errorf("invalid GraphQL document: %w", err)
+		}
+		if token.Kind == lexer.EOF {
+			return "", fmt.Errorf("operation %q not found in the GraphQL document",
+				operation.Name)
+		}
+		if token.Pos.Start != operation.Position.Start {
+			continue
+		}
+
+		// Shorthand query: { ... }
+		if token.Kind == lexer.BraceL {
+			return replace(token.Pos.Start, token.Pos.Start,
+				fmt.Sprintf("query %s ", newName)), nil
+		}
+
+		// The token following the operation type is either the operation
+		// name or, for an anonymous operation, what comes after it.
+		next, err := lex.ReadToken()
+		if err != nil {
+			return "", fmt.Errorf("invalid GraphQL document: %w", err)
+		}
+		if operation.Name != "" && next.Kind == lexer.Name {
+			return replace(next.Pos.Start, next.Pos.End, newName), nil
+		}
+		return replace(token.Pos.End, token.Pos.End, " "+newName), nil
+	}
+}
+
+// GraphQLRequest is a GraphQL request sent to the API.
+type GraphQLRequest struct {
+	// Query is the GraphQL document, holding one or more operations.
+	Query string `json:"query"`
+
+	Variables map[string]interface{} `json:"variables,omitempty"`
+
+	// OperationName selects the operation to execute when the document
+	// holds several. It is prefixed with the OperationName of the client
+	// before being sent.
+	OperationName string `json:"operationName,omitempty"`
+
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+
+	// Mutation marks the request as a mutation. It is not sent to the API,
+	// mutations are not retried unless RetryPolicy.RetryMutations is set.
+	// Requests whose selected operation is a mutation are always treated as
+	// mutations.
+	Mutation bool `json:"-"`
+}
+
+// operationName returns the operation name sent to the API for req.
+func (c *Credentials) operationName(req *GraphQLRequest) string {
+	return c.OperationName + staticOperationName(req)
+}
+
+// fileOperationName returns the operation name sent for the operation of the
+// embedded GraphQL file, as used by the metrics of the Get* methods.
+func (c *Credentials) fileOperationName(file string) string {
+	return c.operationName(&GraphQLRequest{Query: c.readQueryFile(file)})
+}
+
+// staticOperationName returns the name of the operation of req as defined in
+// its GraphQL document.
+func staticOperationName(req *GraphQLRequest) string {
+	if operation, err := selectOperation(req.Query, req.OperationName); err == nil {
+		return operation.Name
+	}
+	return req.OperationName
+}
+
+// isMutation returns true when req is a mutation.
+func isMutation(req *GraphQLRequest) bool {
+	if req.Mutation {
+		return true
+	}
+	operation, err := selectOperation(req.Query, req.OperationName)
+	return err == nil && operation.Operation == ast.Mutation
+}
+
+// prefixOperation returns a copy of req with the operation name, in the
+// request and in the document, prefixed with the OperationName of the
+// client.
+func (c *Credentials) prefixOperation(req *GraphQLRequest) (*GraphQLRequest, error) {
+	operation, err := selectOperation(req.Query, req.OperationName)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixed := *req
+	prefixed.OperationName = c.OperationName + operation.Name
+	prefixed.Query, err = renameOperation(req.Query, operation,
+		prefixed.OperationName)
+	if err != nil {
+		return nil, err
+	}
+	prefixed.Mutation = req.Mutation || operation.Operation == ast.Mutation
+
+	return &prefixed, nil
+}
@@ -0,0 +1,183 @@
+package rubrikpolaris
+
+import (
+	"testing"
+)
+
+func TestPrefixOperation(t *testing.T) {
+	tests := []struct {
+		name          string
+		prefix        string
+		query         string
+		operationName string
+
+		wantQuery         string
+		wantOperationName string
+		wantMutation      bool
+		wantErr           bool
+	}{{
+		name:              "Named",
+		prefix:            "SdkGoLang",
+		query:             "query Clusters { clusterConnection { count } }",
+		wantQuery:         "query SdkGoLangClusters { clusterConnection { count } }",
+		wantOperationName: "SdkGoLangClusters",
+	}, {
+		name:              "NamedWithVariables",
+		prefix:            "SdkGoLang",
+		query:             "query Clusters($first: Int) { clusterConnection(first: $first) { count } }",
+		wantQuery:         "query SdkGoLangClusters($first: Int) { clusterConnection(first: $first) { count } }",
+		wantOperationName: "SdkGoLangClusters",
+	}, {
+		name:              "Shorthand",
+		prefix:            "SdkGoLang",
+		query:             "{ clusterConnection { count } }",
+		wantQuery:         "query SdkGoLang { clusterConnection { count } }",
+		wantOperationName: "SdkGoLang",
+	}, {
+		name:              "ShorthandLeadingComment",
+		prefix:            "SdkGoLang",
+		query:             "# clusters\n{ clusterConnection { count } }",
+		wantQuery:         "# clusters\nquery SdkGoLang { clusterConnection { count } }",
+		wantOperationName: "SdkGoLang",
+	}, {
+		name:              "Anonymous",
+		prefix:            "SdkGoLang",
+		query:             "query { clusterConnection { count } }",
+		wantQuery:         "query SdkGoLang { clusterConnection { count } }",
+		wantOperationName: "SdkGoLang",
+	}, {
+		name:              "AnonymousWithVariables",
+		prefix:            "SdkGoLang",
+		query:             "query ($first: Int) { clusterConnection(first: $first) { count } }",
+		wantQuery:         "query SdkGoLang ($first: Int) { clusterConnection(first: $first) { count } }",
+		wantOperationName: "SdkGoLang",
+	}, {
+		name:              "AnonymousWithVariablesNoSpace",
+		prefix:            "SdkGoLang",
+		query:             "query($first: Int) { clusterConnection(first: $first) { count } }",
+		wantQuery:         "query SdkGoLang($first: Int) { clusterConnection(first: $first) { count } }",
+		wantOperationName: "SdkGoLang",
+	}, {
+		name:              "Mutation",
+		prefix:            "SdkGoLang",
+		query:             "mutation Enable($id: UUID!) { enable(id: $id) { ok } }",
+		wantQuery:         "mutation SdkGoLangEnable($id: UUID!) { enable(id: $id) { ok } }",
+		wantOperationName: "SdkGoLangEnable",
+		wantMutation:      true,
+	}, {
+		name:   "SeveralOperations",
+		prefix: "SdkGoLang",
+		query: "query Clusters { clusterConnection { count } }\n" +
+			"mutation Clusters2 { enable { ok } }\n" +
+			"query Events { activitySeriesConnection { count } }",
+		operationName: "Clusters2",
+		wantQuery: "query Clusters { clusterConnection { count } }\n" +
+			"mutation SdkGoLangClusters2 { enable { ok } }\n" +
+			"query Events { activitySeriesConnection { count } }",
+		wantOperationName: "SdkGoLangClusters2",
+		wantMutation:      true,
+	}, {
+		name:    "SeveralOperationsWithoutName",
+		prefix:  "SdkGoLang",
+		query:   "query A { a } query B { b }",
+		wantErr: true,
+	}, {
+		name:          "UnknownOperation",
+		prefix:        "SdkGoLang",
+		query:         "query A { a }",
+		operationName: "B",
+		wantErr:       true,
+	}, {
+		name:   "Fragments",
+		prefix: "SdkGoLang",
+		query: "fragment ClusterFields on Cluster { id name }\n" +
+			"query Clusters { clusterConnection { nodes { ...ClusterFields } } }",
+		wantQuery: "fragment ClusterFields on Cluster { id name }\n" +
+			"query SdkGoLangClusters { clusterConnection { nodes { ...ClusterFields } } }",
+		wantOperationName: "SdkGoLangClusters",
+	}, {
+		name:   "FragmentNamedLikeOperation",
+		prefix: "SdkGoLang",
+		query: "query Clusters { clusterConnection { nodes { ...Clusters } } }\n" +
+			"fragment Clusters on Cluster { id }",
+		wantQuery: "query SdkGoLangClusters { clusterConnection { nodes { ...Clusters } } }\n" +
+			"fragment Clusters on Cluster { id }",
+		wantOperationName: "SdkGoLangClusters",
+	}, {
+		name:   "MultibyteComments",
+		prefix: "SdkGoLang",
+		query: "# Clústers de sauvegarde — 集群 🚀\n" +
+			"query Clusters { clusterConnection { count } } # fin ✓",
+		wantQuery: "# Clústers de sauvegarde — 集群 🚀\n" +
+			"query SdkGoLangClusters { clusterConnection { count } } # fin ✓",
+		wantOperationName: "SdkGoLangClusters",
+	}, {
+		name:   "MultibyteString",
+		prefix: "SdkGoLang",
+		query: "query A { a(name: \"é🚀\") }\n" +
+			"query B { b }",
+		operationName: "B",
+		wantQuery: "query A { a(name: \"é🚀\") }\n" +
+			"query SdkGoLangB { b }",
+		wantOperationName: "SdkGoLangB",
+	}, {
+		name:              "EmptyPrefix",
+		prefix:            "",
+		query:             "query Clusters { clusterConnection { count } }",
+		wantQuery:         "query Clusters { clusterConnection { count } }",
+		wantOperationName: "Clusters",
+	}, {
+		name:              "EmptyPrefixAnonymous",
+		prefix:            "",
+		query:             "{ clusterConnection { count } }",
+		wantQuery:         "{ clusterConnection { count } }",
+		wantOperationName: "",
+	}, {
+		name:    "InvalidDocument",
+		prefix:  "SdkGoLang",
+		query:   "query Clusters { clusterConnection { count }",
+		wantErr: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Credentials{OperationName: test.prefix}
+			req := &GraphQLRequest{
+				Query:         test.query,
+				OperationName: test.operationName,
+			}
+
+			prefixed, err := c.prefixOperation(req)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", prefixed)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if prefixed.Query != test.wantQuery {
+				t.Errorf("got query:\n%s\nwant:\n%s", prefixed.Query, test.wantQuery)
+			}
+			if prefixed.OperationName != test.wantOperationName {
+				t.Errorf("got operation name %q, want %q", prefixed.OperationName,
+					test.wantOperationName)
+			}
+			if prefixed.Mutation != test.wantMutation {
+				t.Errorf("got mutation %v, want %v", prefixed.Mutation, test.wantMutation)
+			}
+			op, err := selectOperation(prefixed.Query, prefixed.OperationName)
+			if err != nil || op.Name != prefixed.OperationName {
+				t.Errorf("the operation %q is not found in the prefixed query: %v",
+					prefixed.OperationName, err)
+			}
+
+			// The request of the caller is left untouched
+			if req.Query != test.query || req.OperationName != test.operationName {
+				t.Errorf("the request was modified: %+v", req)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package rubrikpolaris
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// redacted replaces the value of secrets in log records.
+const redacted = "[REDACTED]"
+
+// sensitiveKeyParts lists, in lower case, the parts of the keys whose values
+// are redacted from log records and from the GraphQL variables they hold,
+// e.g. password also matches newPassword and current_password.
+var sensitiveKeyParts = []string{
+	"apikey",
+	"authorization",
+	"cookie",
+	"password",
+	"secret",
+	"token",
+}
+
+// isSensitiveKey returns true when the value of key must be redacted. Keys
+// are compared in lower case with their dashes and underscores removed, so
+// that api_key and Set-Cookie are matched as well.
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	key = strings.NewReplacer("-", "", "_", "").Replace(key)
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactValue returns a copy of v, a decoded JSON value, with the values of
+// sensitive keys redacted.
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		redactedMap := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			if isSensitiveKey(key) {
+				redactedMap[key] = redacted
+			} else {
+				redactedMap[key] = redactValue(value)
+			}
+		}
+		return redactedMap
+	case []interface{}:
+		redactedSlice := make([]interface{}, len(v))
+		for i, value := range v {
+			redactedSlice[i] = redactValue(value)
+		}
+		return redactedSlice
+	case string:
+		if strings.HasPrefix(strings.ToLower(v), "bearer ") ||
+			strings.HasPrefix(strings.ToLower(v), "basic ") {
+			return redacted
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// redactingHandler is a slog.Handler redacting the attributes holding
+// secrets before passing the records on to the wrapped handler.
+type redactingHandler struct {
+	handler slog.Handler
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context,
+	level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context,
+	record slog.Record) error {
+
+	redactedRecord := slog.NewRecord(record.Time, record.Level,
+		record.Message, record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redactedRecord.AddAttrs(redactAttr(attr))
+		return true
+	})
+	return h.handler.Handle(ctx, redactedRecord)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redactedAttrs := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redactedAttrs[i] = redactAttr(attr)
+	}
+	return &redactingHandler{handler: h.handler.WithAttrs(redactedAttrs)}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{handler: h.handler.WithGroup(name)}
+}
+
+// redactAttr redacts attr when its key is sensitive, when it holds a bearer
+// or basic authorization value, or when it is an HTTP header set.
+func redactAttr(attr slog.Attr) slog.Attr {
+	if isSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, redacted)
+	}
+
+	value := attr.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindGroup:
+		group := value.Group()
+		redactedGroup := make([]any, len(group))
+		for i, groupAttr := range group {
+			redactedGroup[i] = redactAttr(groupAttr)
+		}
+		return slog.Group(attr.Key, redactedGroup...)
+	case slog.KindString:
+		return slog.Any(attr.Key, redactValue(value.String()))
+	case slog.KindAny:
+		switch v := value.Any().(type) {
+		case http.Header:
+			return slog.Any(attr.Key, redactHeader(v))
+		case map[string]interface{}, []interface{}:
+			return slog.Any(attr.Key, redactValue(v))
+		}
+	}
+	return slog.Attr{Key: attr.Key, Value: value}
+}
+
+// redactHeader returns a copy of header with the values of the sensitive
+// headers redacted.
+func redactHeader(header http.Header) http.Header {
+	redactedHeader := make(http.Header, len(header))
+	for key, values := range header {
+		if isSensitiveKey(key) {
+			redactedHeader[key] = []string{redacted}
+		} else {
+			redactedHeader[key] = values
+		}
+	}
+	return redactedHeader
+}
+
+// discardHandler is a slog.Handler dropping all records, used when no
+// Logger is set.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// logger returns the logger of the client. Secrets are redacted from all
+// records, and nothing is logged when no Logger is set.
+func (c *Credentials) logger() *slog.Logger {
+	if c.Logger == nil {
+		return slog.New(discardHandler{})
+	}
+	return slog.New(&redactingHandler{handler: c.Logger.Handler()})
+}
+
+// logRequest logs a request sent by commonAPI.
+func (c *Credentials) logRequest(ctx context.Context, req *APIRequest,
+	resp *http.Response, err error, duration time.Duration) {
+
+	attrs := []slog.Attr{
+		slog.String("call_type", req.CallType),
+		slog.Duration("duration", duration),
+	}
+	if req.CallType == CallTypeGraphQL {
+		attrs = append(attrs,
+			slog.String("operation", req.OperationName),
+			slog.Any("variables", redactValue(req.Variables)))
+		if cursor, ok := req.Variables["after"].(string); ok {
+			attrs = append(attrs, slog.String("cursor", cursor))
+		}
+	}
+
+	level := slog.LevelDebug
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			level = slog.LevelWarn
+		}
+	}
+	if err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	c.logger().LogAttrs(ctx, level, "rubrik polaris API request", attrs...)
+}
+
+// logPage logs the progress of a paginated query.
+func (c *Credentials) logPage(ctx context.Context, operation string,
+	page, items int, hasNextPage bool, cursor string) {
+
+	c.logger().LogAttrs(ctx, slog.LevelDebug, "rubrik polaris page fetched",
+		slog.String("operation", operation),
+		slog.Int("page", page),
+		slog.Int("items", items),
+		slog.Bool("has_next_page", hasNextPage),
+		slog.String("cursor", cursor))
+}
+
+// logPagination logs the completion of a paginated query.
+func (c *Credentials) logPagination(ctx context.Context, operation string,
+	pages, items int) {
+
+	c.logger().LogAttrs(ctx, slog.LevelInfo, "rubrik polaris pages fetched",
+		slog.String("operation", operation),
+		slog.Int("pages", pages),
+		slog.Int("items", items))
+}
@@ -0,0 +1,86 @@
+package rubrikpolaris
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestIsSensitiveKey(t *testing.T) {
+	for _, key := range []string{
+		"password", "newPassword", "current_password", "PASSWORD",
+		"secret", "client_secret", "clientSecret",
+		"token", "access_token", "apiToken", "refreshToken",
+		"authorization", "Proxy-Authorization",
+		"apiKey", "api_key", "X-Api-Key",
+		"Cookie", "Set-Cookie",
+	} {
+		if !isSensitiveKey(key) {
+			t.Errorf("%s is not sensitive", key)
+		}
+	}
+
+	for _, key := range []string{
+		"operation", "variables", "cursor", "clusterId", "timeAgo", "after",
+		"Content-Type", "X-Request-Id",
+	} {
+		if isSensitiveKey(key) {
+			t.Errorf("%s is sensitive", key)
+		}
+	}
+}
+
+// TestLogRedactsNestedVariables checks that the secrets held by nested
+// GraphQL variables are redacted from the records of failed requests.
+func TestLogRedactsNestedVariables(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	var buf bytes.Buffer
+	c.Logger = slog.New(slog.NewTextHandler(&buf, nil))
+
+	c.MutationWithVariables("mutation ChangePassword($input: Input!) { ok }",
+		map[string]interface{}{
+			"input": map[string]interface{}{
+				"user":        "admin",
+				"newPassword": "hunter3",
+				"credentials": []interface{}{
+					map[string]interface{}{"apiToken": "tok-123", "apiKey": "key-456"},
+				},
+			},
+		})
+
+	record := buf.String()
+	if !strings.Contains(record, "level=WARN") || !strings.Contains(record, "admin") {
+		t.Fatalf("the failed request was not logged with its variables: %s", record)
+	}
+	for _, secret := range []string{"hunter3", "tok-123", "key-456"} {
+		if strings.Contains(record, secret) {
+			t.Errorf("%s is not redacted: %s", secret, record)
+		}
+	}
+}
+
+func TestRedactAttrHeader(t *testing.T) {
+	header := http.Header{
+		"Cookie":        {"session=abc"},
+		"Set-Cookie":    {"session=def"},
+		"X-Api-Key":     {"key-456"},
+		"Authorization": {"Bearer tok-123"},
+		"Content-Type":  {"application/json"},
+	}
+
+	attr := redactAttr(slog.Any("header", header))
+	redactedHeader := attr.Value.Any().(http.Header)
+	for key := range header {
+		want := redacted
+		if key == "Content-Type" {
+			want = "application/json"
+		}
+		if got := redactedHeader.Get(key); got != want {
+			t.Errorf("%s: got %q, want %q", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package rubrikpolaris
+
+import (
+	"time"
+)
+
+// Metrics receives measurements about the API usage of the clients it is set
+// on. The prommetrics package implements it with Prometheus metrics, so
+// that only the programs using them depend on Prometheus. Implementations
+// must be safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest records a request and its latency. statusCode is the
+	// HTTP status code of the response, zero when no response was received.
+	ObserveRequest(callType, operation string, statusCode int,
+		duration time.Duration)
+
+	// ObserveTokenRefresh records a token request, err is its error.
+	ObserveTokenRefresh(err error)
+
+	// ObserveRetry records a request retried after a transient failure.
+	ObserveRetry(operation string)
+
+	// ObservePage records a page fetched by a paginated query.
+	ObservePage(operation string)
+
+	// ObserveEvents records count events returned by an event query.
+	ObserveEvents(operation string, count int)
+}
+
+// noMetrics is the Metrics of the clients without metrics.
+type noMetrics struct{}
+
+func (noMetrics) ObserveRequest(string, string, int, time.Duration) {}
+func (noMetrics) ObserveTokenRefresh(error)                         {}
+func (noMetrics) ObserveRetry(string)                               {}
+func (noMetrics) ObservePage(string)                                {}
+func (noMetrics) ObserveEvents(string, int)                         {}
+
+// metrics returns the Metrics of the client, which discards everything when
+// no Metrics is set.
+func (c *Credentials) metrics() Metrics {
+	if c.Metrics == nil {
+		return noMetrics{}
+	}
+	return c.Metrics
+}
@@ -0,0 +1,78 @@
+package rubrikpolaris
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Call types of the requests sent by the client.
+const (
+	// CallTypeGraphQL is a GraphQL request sent to /api/graphql.
+	CallTypeGraphQL = "graphql"
+
+	// CallTypeServiceAccount is a client_credentials token request sent to
+	// the access token URI of a service account.
+	CallTypeServiceAccount = "serviceAccount"
+
+	// CallTypeSession is a username/password token request sent to
+	// /api/session.
+	CallTypeSession = "session"
+)
+
+// APIRequest describes a request sent by the client, as seen by Middleware.
+type APIRequest struct {
+	// CallType is one of CallTypeGraphQL, CallTypeServiceAccount or
+	// CallTypeSession.
+	CallType string
+
+	// OperationName and Variables are the operation name and variables of
+	// GraphQL requests, they are empty for token requests.
+	OperationName string
+	Variables     map[string]interface{}
+
+	// Mutation is true for GraphQL mutations.
+	Mutation bool
+
+	// HTTPRequest is the HTTP request about to be sent. Middleware can
+	// modify its headers. Its body holds the JSON encoded request and
+	// must not be consumed.
+	HTTPRequest *http.Request
+}
+
+// Handler sends an APIRequest and returns the HTTP response.
+type Handler func(req *APIRequest) (*http.Response, error)
+
+// Middleware wraps the Handler sending the requests of the client. A
+// Middleware sees every request the client sends, including token requests,
+// and the response returned by next. It can modify the request headers
+// before calling next, inspect the response, or short-circuit the call by
+// returning a response without calling next.
+type Middleware func(next Handler) Handler
+
+// send sends req through the Middleware chain of the client, the first
+// Middleware being the outermost one.
+func (c *Credentials) send(client *http.Client,
+	req *APIRequest) (*http.Response, error) {
+
+	handler := Handler(func(req *APIRequest) (*http.Response, error) {
+		return client.Do(req.HTTPRequest)
+	})
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		handler = c.Middleware[i](handler)
+	}
+
+	resp, err := handler(req)
+	if err != nil {
+		if resp != nil && resp.Body != nil {
+			resp.Body.Close()
+		}
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errors.New("middleware returned no response")
+	}
+	if resp.Body == nil {
+		resp.Body = http.NoBody
+	}
+	return resp, nil
+}
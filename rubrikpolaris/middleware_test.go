@@ -0,0 +1,47 @@
+package rubrikpolaris
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewareNoResponse(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the request was sent to the API")
+	})
+	c.Middleware = []Middleware{func(next Handler) Handler {
+		return func(req *APIRequest) (*http.Response, error) {
+			if req.CallType == CallTypeGraphQL {
+				return nil, nil
+			}
+			return next(req)
+		}
+	}}
+
+	_, err := c.Query("query Test { ok }")
+	if err == nil || !strings.Contains(err.Error(), "middleware returned no response") {
+		t.Fatalf("got error %v, want middleware returned no response", err)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the request was sent to the API")
+	})
+	c.Middleware = []Middleware{func(next Handler) Handler {
+		return func(req *APIRequest) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     "200 OK",
+				Header:     http.Header{},
+			}, nil
+		}
+	}}
+
+	// A response without a body is read as an empty body, which is not
+	// valid JSON
+	if _, err := c.Query("query Test { ok }"); err == nil {
+		t.Fatal("expected a decoding error")
+	}
+}
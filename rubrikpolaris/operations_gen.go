@@ -0,0 +1,392 @@
+package rubrikpolaris
+
+// Code generated by go generate; DO NOT EDIT.
+
+import "context"
+
+// AllAuditLogPerTimePeriodVariables holds the variables of the AllAuditLogPerTimePeriod query.
+type AllAuditLogPerTimePeriodVariables struct {
+	TimeAgo *string `json:"timeAgo,omitempty"`
+}
+
+// AllAuditLogPerTimePeriodResponse holds the data returned by the AllAuditLogPerTimePeriod query.
+type AllAuditLogPerTimePeriodResponse struct {
+	UserAuditConnection struct {
+		Edges []struct {
+			Node struct {
+				ID       string `json:"id"`
+				Message  string `json:"message"`
+				Time     string `json:"time"`
+				Severity string `json:"severity"`
+				Status   string `json:"status"`
+				Cluster  *struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"cluster"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"userAuditConnection"`
+}
+
+// AllAuditLogPerTimePeriod sends the AllAuditLogPerTimePeriod query of AllAuditLogPerTimePeriod.graphql.
+func (c *Credentials) AllAuditLogPerTimePeriod(vars *AllAuditLogPerTimePeriodVariables, timeout ...int) (*AllAuditLogPerTimePeriodResponse, error) {
+	return c.AllAuditLogPerTimePeriodContext(context.Background(), vars, timeout...)
+}
+
+// AllAuditLogPerTimePeriodContext is like AllAuditLogPerTimePeriod but takes a context which cancels
+// the underlying requests when done.
+func (c *Credentials) AllAuditLogPerTimePeriodContext(ctx context.Context, vars *AllAuditLogPerTimePeriodVariables, timeout ...int) (*AllAuditLogPerTimePeriodResponse, error) {
+	return doOperation[AllAuditLogPerTimePeriodResponse](ctx, c, "AllAuditLogPerTimePeriod.graphql", vars, false, timeout...)
+}
+
+// AllEventsPerTimePeriodVariables holds the variables of the AllEventsPerTimePeriod query.
+type AllEventsPerTimePeriodVariables struct {
+	TimeAgo *string `json:"timeAgo,omitempty"`
+}
+
+// AllEventsPerTimePeriodResponse holds the data returned by the AllEventsPerTimePeriod query.
+type AllEventsPerTimePeriodResponse struct {
+	ActivitySeriesConnection struct {
+		Edges []struct {
+			Node struct {
+				ID                   int64   `json:"id"`
+				Fid                  string  `json:"fid"`
+				ActivitySeriesID     string  `json:"activitySeriesId"`
+				LastUpdated          string  `json:"lastUpdated"`
+				LastActivityType     string  `json:"lastActivityType"`
+				LastActivityStatus   string  `json:"lastActivityStatus"`
+				ObjectID             string  `json:"objectId"`
+				ObjectName           string  `json:"objectName"`
+				ObjectType           string  `json:"objectType"`
+				Severity             string  `json:"severity"`
+				Progress             *string `json:"progress"`
+				IsCancelable         *bool   `json:"isCancelable"`
+				IsPolarisEventSeries bool    `json:"isPolarisEventSeries"`
+				Cluster              *struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"cluster"`
+				ActivityConnection struct {
+					Nodes []struct {
+						ID      string `json:"id"`
+						Message string `json:"message"`
+					} `json:"nodes"`
+				} `json:"activityConnection"`
+			} `json:"node"`
+		} `json:"edges"`
+		PageInfo struct {
+			EndCursor       *string `json:"endCursor"`
+			HasNextPage     bool    `json:"hasNextPage"`
+			HasPreviousPage bool    `json:"hasPreviousPage"`
+		} `json:"pageInfo"`
+	} `json:"activitySeriesConnection"`
+}
+
+// AllEventsPerTimePeriod sends the AllEventsPerTimePeriod query of AllEventsPerTimePeriod.graphql.
+func (c *Credentials) AllEventsPerTimePeriod(vars *AllEventsPerTimePeriodVariables, timeout ...int) (*AllEventsPerTimePeriodResponse, error) {
+	return c.AllEventsPerTimePeriodContext(context.Background(), vars, timeout...)
+}
+
+// AllEventsPerTimePeriodContext is like AllEventsPerTimePeriod but takes a context which cancels
+// the underlying requests when done.
+func (c *Credentials) AllEventsPerTimePeriodContext(ctx context.Context, vars *AllEventsPerTimePeriodVariables, timeout ...int) (*AllEventsPerTimePeriodResponse, error) {
+	return doOperation[AllEventsPerTimePeriodResponse](ctx, c, "AllEventsPerTimePeriod.graphql", vars, false, timeout...)
+}
+
+// AllPolarisEventPerTimePeriodVariables holds the variables of the AllPolarisEventPerTimePeriod query.
+type AllPolarisEventPerTimePeriodVariables struct {
+	TimeAgo   *string `json:"timeAgo,omitempty"`
+	ClusterID *string `json:"clusterId,omitempty"`
+	After     *string `json:"after,omitempty"`
+}
+
+// AllPolarisEventPerTimePeriodResponse holds the data returned by the AllPolarisEventPerTimePeriod query.
+type AllPolarisEventPerTimePeriodResponse struct {
+	ActivitySeriesConnection struct {
+		Edges []struct {
+			Node struct {
+				ID                 int64   `json:"id"`
+				Fid                string  `json:"fid"`
+				ActivitySeriesID   string  `json:"activitySeriesId"`
+				LastUpdated        string  `json:"lastUpdated"`
+				LastActivityType   string  `json:"lastActivityType"`
+				LastActivityStatus string  `json:"lastActivityStatus"`
+				ObjectID           string  `json:"objectId"`
+				ObjectName         string  `json:"objectName"`
+				ObjectType         string  `json:"objectType"`
+				Severity           string  `json:"severity"`
+				Progress           *string `json:"progress"`
+				Cluster            *struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"cluster"`
+				ActivityConnection struct {
+					Nodes []struct {
+						ID      string `json:"id"`
+						Message string `json:"message"`
+						Time    string `json:"time"`
+					} `json:"nodes"`
+				} `json:"activityConnection"`
+			} `json:"node"`
+		} `json:"edges"`
+		PageInfo struct {
+			EndCursor       *string `json:"endCursor"`
+			HasNextPage     bool    `json:"hasNextPage"`
+			HasPreviousPage bool    `json:"hasPreviousPage"`
+		} `json:"pageInfo"`
+	} `json:"activitySeriesConnection"`
+}
+
+// AllPolarisEventPerTimePeriod sends the AllPolarisEventPerTimePeriod query of AllPolarisEventPerTimePeriod.graphql.
+func (c *Credentials) AllPolarisEventPerTimePeriod(vars *AllPolarisEventPerTimePeriodVariables, timeout ...int) (*AllPolarisEventPerTimePeriodResponse, error) {
+	return c.AllPolarisEventPerTimePeriodContext(context.Background(), vars, timeout...)
+}
+
+// AllPolarisEventPerTimePeriodContext is like AllPolarisEventPerTimePeriod but takes a context which cancels
+// the underlying requests when done.
+func (c *Credentials) AllPolarisEventPerTimePeriodContext(ctx context.Context, vars *AllPolarisEventPerTimePeriodVariables, timeout ...int) (*AllPolarisEventPerTimePeriodResponse, error) {
+	return doOperation[AllPolarisEventPerTimePeriodResponse](ctx, c, "AllPolarisEventPerTimePeriod.graphql", vars, false, timeout...)
+}
+
+// ClusterListFilterQueryVariables holds the variables of the ClusterListFilterQuery query.
+type ClusterListFilterQueryVariables struct {
+	ClusterNames []string `json:"clusterNames,omitempty"`
+	After        *string  `json:"after,omitempty"`
+}
+
+// ClusterListFilterQueryResponse holds the data returned by the ClusterListFilterQuery query.
+type ClusterListFilterQueryResponse struct {
+	ClusterConnection struct {
+		Edges []struct {
+			Node struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"node"`
+		} `json:"edges"`
+		PageInfo struct {
+			EndCursor   *string `json:"endCursor"`
+			HasNextPage bool    `json:"hasNextPage"`
+		} `json:"pageInfo"`
+	} `json:"clusterConnection"`
+}
+
+// ClusterListFilterQuery sends the ClusterListFilterQuery query of CDMClusterIdByName.graphql.
+func (c *Credentials) ClusterListFilterQuery(vars *ClusterListFilterQueryVariables, timeout ...int) (*ClusterListFilterQueryResponse, error) {
+	return c.ClusterListFilterQueryContext(context.Background(), vars, timeout...)
+}
+
+// ClusterListFilterQueryContext is like ClusterListFilterQuery but takes a context which cancels
+// the underlying requests when done.
+func (c *Credentials) ClusterListFilterQueryContext(ctx context.Context, vars *ClusterListFilterQueryVariables, timeout ...int) (*ClusterListFilterQueryResponse, error) {
+	return doOperation[ClusterListFilterQueryResponse](ctx, c, "CDMClusterIdByName.graphql", vars, false, timeout...)
+}
+
+// ToggleRadarPrefsMutationVariables holds the variables of the ToggleRadarPrefsMutation mutation.
+type ToggleRadarPrefsMutationVariables struct {
+	ClusterID string `json:"clusterId"`
+}
+
+// ToggleRadarPrefsMutationResponse holds the data returned by the ToggleRadarPrefsMutation mutation.
+type ToggleRadarPrefsMutationResponse struct {
+	EnableAutomaticFmdUpload struct {
+		ClusterID string `json:"clusterId"`
+		Enabled   bool   `json:"enabled"`
+	} `json:"enableAutomaticFmdUpload"`
+}
+
+// ToggleRadarPrefsMutation sends the ToggleRadarPrefsMutation mutation of EnableRadar.graphql.
+func (c *Credentials) ToggleRadarPrefsMutation(vars *ToggleRadarPrefsMutationVariables, timeout ...int) (*ToggleRadarPrefsMutationResponse, error) {
+	return c.ToggleRadarPrefsMutationContext(context.Background(), vars, timeout...)
+}
+
+// ToggleRadarPrefsMutationContext is like ToggleRadarPrefsMutation but takes a context which cancels
+// the underlying requests when done.
+func (c *Credentials) ToggleRadarPrefsMutationContext(ctx context.Context, vars *ToggleRadarPrefsMutationVariables, timeout ...int) (*ToggleRadarPrefsMutationResponse, error) {
+	return doOperation[ToggleRadarPrefsMutationResponse](ctx, c, "EnableRadar.graphql", vars, true, timeout...)
+}
+
+// EventDetailsVariables holds the variables of the EventDetails query.
+type EventDetailsVariables struct {
+	ActivitySeriesID string `json:"activitySeriesId"`
+	ClusterUUID      string `json:"clusterUuid"`
+}
+
+// EventDetailsResponse holds the data returned by the EventDetails query.
+type EventDetailsResponse struct {
+	ActivitySeries struct {
+		ActivityConnection struct {
+			Nodes []struct {
+				Message  string `json:"message"`
+				Status   string `json:"status"`
+				Time     string `json:"time"`
+				Severity string `json:"severity"`
+			} `json:"nodes"`
+		} `json:"activityConnection"`
+		ID               int64  `json:"id"`
+		Fid              string `json:"fid"`
+		ActivitySeriesID string `json:"activitySeriesId"`
+		ObjectID         string `json:"objectId"`
+		ObjectName       string `json:"objectName"`
+		ObjectType       string `json:"objectType"`
+		Cluster          *struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"cluster"`
+		LastActivityStatus string `json:"lastActivityStatus"`
+	} `json:"activitySeries"`
+}
+
+// EventDetails sends the EventDetails query of EventDetails.graphql.
+func (c *Credentials) EventDetails(vars *EventDetailsVariables, timeout ...int) (*EventDetailsResponse, error) {
+	return c.EventDetailsContext(context.Background(), vars, timeout...)
+}
+
+// EventDetailsContext is like EventDetails but takes a context which cancels
+// the underlying requests when done.
+func (c *Credentials) EventDetailsContext(ctx context.Context, vars *EventDetailsVariables, timeout ...int) (*EventDetailsResponse, error) {
+	return doOperation[EventDetailsResponse](ctx, c, "EventDetails.graphql", vars, false, timeout...)
+}
+
+// RadarEnabledClustersResponse holds the data returned by the RadarEnabledClusters query.
+type RadarEnabledClustersResponse struct {
+	RadarClusterConnection struct {
+		Nodes []struct {
+			LambdaConfig *struct {
+				ClusterID                string `json:"clusterId"`
+				EnableAutomaticFmdUpload bool   `json:"enableAutomaticFmdUpload"`
+			} `json:"lambdaConfig"`
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"radarClusterConnection"`
+}
+
+// RadarEnabledClusters sends the RadarEnabledClusters query of RadarEnabledClusters.graphql.
+func (c *Credentials) RadarEnabledClusters(timeout ...int) (*RadarEnabledClustersResponse, error) {
+	return c.RadarEnabledClustersContext(context.Background(), timeout...)
+}
+
+// RadarEnabledClustersContext is like RadarEnabledClusters but takes a context which cancels
+// the underlying requests when done.
+func (c *Credentials) RadarEnabledClustersContext(ctx context.Context, timeout ...int) (*RadarEnabledClustersResponse, error) {
+	return doOperation[RadarEnabledClustersResponse](ctx, c, "RadarEnabledClusters.graphql", nil, false, timeout...)
+}
+
+// RadarEventCountVariables holds the variables of the RadarEventCount query.
+type RadarEventCountVariables struct {
+	Since *string `json:"since,omitempty"`
+	Until *string `json:"until,omitempty"`
+}
+
+// RadarEventCountResponse holds the data returned by the RadarEventCount query.
+type RadarEventCountResponse struct {
+	ActivitySeriesConnection struct {
+		Count int `json:"count"`
+	} `json:"activitySeriesConnection"`
+}
+
+// RadarEventCount sends the RadarEventCount query of RadarEventCount.graphql.
+func (c *Credentials) RadarEventCount(vars *RadarEventCountVariables, timeout ...int) (*RadarEventCountResponse, error) {
+	return c.RadarEventCountContext(context.Background(), vars, timeout...)
+}
+
+// RadarEventCountContext is like RadarEventCount but takes a context which cancels
+// the underlying requests when done.
+func (c *Credentials) RadarEventCountContext(ctx context.Context, vars *RadarEventCountVariables, timeout ...int) (*RadarEventCountResponse, error) {
+	return doOperation[RadarEventCountResponse](ctx, c, "RadarEventCount.graphql", vars, false, timeout...)
+}
+
+// RadarEventsPerTimePeriodVariables holds the variables of the RadarEventsPerTimePeriod query.
+type RadarEventsPerTimePeriodVariables struct {
+	TimeAgo *string `json:"timeAgo,omitempty"`
+}
+
+// RadarEventsPerTimePeriodResponse holds the data returned by the RadarEventsPerTimePeriod query.
+type RadarEventsPerTimePeriodResponse struct {
+	ActivitySeriesConnection struct {
+		Edges []struct {
+			Node struct {
+				ID                 int64   `json:"id"`
+				Fid                string  `json:"fid"`
+				ActivitySeriesID   string  `json:"activitySeriesId"`
+				LastUpdated        string  `json:"lastUpdated"`
+				LastActivityType   string  `json:"lastActivityType"`
+				LastActivityStatus string  `json:"lastActivityStatus"`
+				ObjectID           string  `json:"objectId"`
+				ObjectName         string  `json:"objectName"`
+				ObjectType         string  `json:"objectType"`
+				Severity           string  `json:"severity"`
+				Progress           *string `json:"progress"`
+				Cluster            *struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"cluster"`
+				ActivityConnection struct {
+					Nodes []struct {
+						ID      string `json:"id"`
+						Message string `json:"message"`
+						Time    string `json:"time"`
+					} `json:"nodes"`
+				} `json:"activityConnection"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"activitySeriesConnection"`
+}
+
+// RadarEventsPerTimePeriod sends the RadarEventsPerTimePeriod query of RadarEventsPerTimePeriod.graphql.
+func (c *Credentials) RadarEventsPerTimePeriod(vars *RadarEventsPerTimePeriodVariables, timeout ...int) (*RadarEventsPerTimePeriodResponse, error) {
+	return c.RadarEventsPerTimePeriodContext(context.Background(), vars, timeout...)
+}
+
+// RadarEventsPerTimePeriodContext is like RadarEventsPerTimePeriod but takes a context which cancels
+// the underlying requests when done.
+func (c *Credentials) RadarEventsPerTimePeriodContext(ctx context.Context, vars *RadarEventsPerTimePeriodVariables, timeout ...int) (*RadarEventsPerTimePeriodResponse, error) {
+	return doOperation[RadarEventsPerTimePeriodResponse](ctx, c, "RadarEventsPerTimePeriod.graphql", vars, false, timeout...)
+}
+
+// RadarSonarEventsPerTimePeriodVariables holds the variables of the RadarSonarEventsPerTimePeriod query.
+type RadarSonarEventsPerTimePeriodVariables struct {
+	TimeAgo *string `json:"timeAgo,omitempty"`
+}
+
+// RadarSonarEventsPerTimePeriodResponse holds the data returned by the RadarSonarEventsPerTimePeriod query.
+type RadarSonarEventsPerTimePeriodResponse struct {
+	ActivitySeriesConnection struct {
+		Edges []struct {
+			Node struct {
+				ID                 int64   `json:"id"`
+				Fid                string  `json:"fid"`
+				ActivitySeriesID   string  `json:"activitySeriesId"`
+				LastUpdated        string  `json:"lastUpdated"`
+				LastActivityType   string  `json:"lastActivityType"`
+				LastActivityStatus string  `json:"lastActivityStatus"`
+				ObjectID           string  `json:"objectId"`
+				ObjectName         string  `json:"objectName"`
+				ObjectType         string  `json:"objectType"`
+				Severity           string  `json:"severity"`
+				Progress           *string `json:"progress"`
+				Cluster            *struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+				} `json:"cluster"`
+				ActivityConnection struct {
+					Nodes []struct {
+						ID      string `json:"id"`
+						Message string `json:"message"`
+						Time    string `json:"time"`
+					} `json:"nodes"`
+				} `json:"activityConnection"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"activitySeriesConnection"`
+}
+
+// RadarSonarEventsPerTimePeriod sends the RadarSonarEventsPerTimePeriod query of RadarSonarEventsPerTimePeriod.graphql.
+func (c *Credentials) RadarSonarEventsPerTimePeriod(vars *RadarSonarEventsPerTimePeriodVariables, timeout ...int) (*RadarSonarEventsPerTimePeriodResponse, error) {
+	return c.RadarSonarEventsPerTimePeriodContext(context.Background(), vars, timeout...)
+}
+
+// RadarSonarEventsPerTimePeriodContext is like RadarSonarEventsPerTimePeriod but takes a context which cancels
+// the underlying requests when done.
+func (c *Credentials) RadarSonarEventsPerTimePeriodContext(ctx context.Context, vars *RadarSonarEventsPerTimePeriodVariables, timeout ...int) (*RadarSonarEventsPerTimePeriodResponse, error) {
+	return doOperation[RadarSonarEventsPerTimePeriodResponse](ctx, c, "RadarSonarEventsPerTimePeriod.graphql", vars, false, timeout...)
+}
@@ -0,0 +1,132 @@
+// Package prommetrics implements the Metrics of the rubrikpolaris clients
+// with Prometheus metrics:
+//
+//	metrics, err := prommetrics.NewMetrics(nil)
+//	if err != nil {
+//		return err
+//	}
+//	client.Metrics = metrics
+package prommetrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rubrikinc/rubrik-polaris-sdk-for-go-deprecated/rubrikpolaris"
+)
+
+// metricsNamespace prefixes the names of the Prometheus metrics of the SDK.
+const metricsNamespace = "rubrik_polaris"
+
+// Metrics collects Prometheus metrics about the API usage of the clients it
+// is set on. A single Metrics can be shared by several clients.
+type Metrics struct {
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	tokenRefreshes  *prometheus.CounterVec
+	retries         *prometheus.CounterVec
+	pages           *prometheus.CounterVec
+	events          *prometheus.CounterVec
+}
+
+// NewMetrics creates the Prometheus metrics of the SDK and registers them
+// with registerer, prometheus.DefaultRegisterer when nil. The metrics are:
+//
+//	rubrik_polaris_requests_total{call_type,operation,status}
+//	rubrik_polaris_request_duration_seconds{call_type,operation}
+//	rubrik_polaris_token_refreshes_total{result}
+//	rubrik_polaris_retries_total{operation}
+//	rubrik_polaris_pages_fetched_total{operation}
+//	rubrik_polaris_events_returned_total{operation}
+//
+// The status label holds the HTTP status code of the response, or "error"
+// when no response was received.
+func NewMetrics(registerer prometheus.Registerer) (*Metrics, error) {
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "requests_total",
+			Help:      "Number of requests sent to the Rubrik Polaris API.",
+		}, []string{"call_type", "operation", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of the requests sent to the Rubrik Polaris API.",
+			Buckets:   prometheus.ExponentialBuckets(0.05, 2, 12),
+		}, []string{"call_type", "operation"}),
+		tokenRefreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "token_refreshes_total",
+			Help:      "Number of API token requests, by result.",
+		}, []string{"result"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "retries_total",
+			Help:      "Number of GraphQL requests retried after a transient failure.",
+		}, []string{"operation"}),
+		pages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "pages_fetched_total",
+			Help:      "Number of pages fetched by paginated queries.",
+		}, []string{"operation"}),
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "events_returned_total",
+			Help:      "Number of events returned by event queries.",
+		}, []string{"operation"}),
+	}
+
+	for _, collector := range []prometheus.Collector{
+		m.requests, m.requestDuration, m.tokenRefreshes, m.retries, m.pages,
+		m.events} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+var _ rubrikpolaris.Metrics = (*Metrics)(nil)
+
+// ObserveRequest records a request and its latency. statusCode is zero when
+// no response was received.
+func (m *Metrics) ObserveRequest(callType, operation string, statusCode int,
+	duration time.Duration) {
+
+	status := "error"
+	if statusCode != 0 {
+		status = strconv.Itoa(statusCode)
+	}
+	m.requests.WithLabelValues(callType, operation, status).Inc()
+	m.requestDuration.WithLabelValues(callType, operation).
+		Observe(duration.Seconds())
+}
+
+// ObserveTokenRefresh records a token request.
+func (m *Metrics) ObserveTokenRefresh(err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	m.tokenRefreshes.WithLabelValues(result).Inc()
+}
+
+// ObserveRetry records a retried request.
+func (m *Metrics) ObserveRetry(operation string) {
+	m.retries.WithLabelValues(operation).Inc()
+}
+
+// ObservePage records a page fetched by a paginated query.
+func (m *Metrics) ObservePage(operation string) {
+	m.pages.WithLabelValues(operation).Inc()
+}
+
+// ObserveEvents records events returned by an event query.
+func (m *Metrics) ObserveEvents(operation string, count int) {
+	m.events.WithLabelValues(operation).Add(float64(count))
+}
@@ -0,0 +1,73 @@
+package prommetrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rubrikinc/rubrik-polaris-sdk-for-go-deprecated/rubrikpolaris"
+)
+
+func TestMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"ok": true}}`))
+	}))
+	defer server.Close()
+
+	registry := prometheus.NewRegistry()
+	metrics, err := NewMetrics(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := rubrikpolaris.ConnectTokenSource("test",
+		rubrikpolaris.TokenSourceFunc(func(ctx context.Context) (*rubrikpolaris.Token, error) {
+			return &rubrikpolaris.Token{
+				AccessToken: "token",
+				ExpiresAt:   time.Now().Add(time.Hour),
+			}, nil
+		}))
+	client.BaseURL = server.URL
+	client.Metrics = metrics
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Query("query Test { ok }"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := map[string]float64{}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			switch {
+			case metric.GetCounter() != nil:
+				counts[family.GetName()] += metric.GetCounter().GetValue()
+			case metric.GetHistogram() != nil:
+				counts[family.GetName()] += float64(metric.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+
+	want := map[string]float64{
+		"rubrik_polaris_requests_total":           2,
+		"rubrik_polaris_request_duration_seconds": 2,
+		"rubrik_polaris_token_refreshes_total":    1,
+	}
+	for name, value := range want {
+		if counts[name] != value {
+			t.Errorf("%s: got %v, want %v", name, counts[name], value)
+		}
+	}
+
+	// The metrics are registered once per registry
+	if _, err := NewMetrics(registry); err == nil {
+		t.Error("expected an error registering the metrics twice")
+	}
+}
@@ -0,0 +1,376 @@
+package rubrikpolaris
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// DefaultConfigFile is the config file holding the named profiles read
+	// by ConnectDefault.
+	DefaultConfigFile = "~/.rubrik/config"
+
+	// DefaultProfile is the profile read by ConnectDefault when no profile
+	// name is given.
+	DefaultProfile = "default"
+)
+
+// ConnectOptions holds the explicitly provided credentials and settings used
+// by ConnectDefault. All fields are optional.
+type ConnectOptions struct {
+	// Service account credentials, either as a JSON string or as the path
+	// of a service account file.
+	ServiceAccountJSON string
+	ServiceAccountFile string
+
+	// BaseURL is the base URL of the API, including scheme and optional
+	// port. It replaces PolarisDomain for deployments outside of
+	// my.rubrik.com.
+	BaseURL string
+
+	// User account credentials.
+	PolarisDomain string
+	Username      string
+	Password      string
+
+	// Profile is the name of the profile to read from ConfigFile. When
+	// empty, the rubrik_polaris_profile environment variable is used, and
+	// then DefaultProfile.
+	Profile string
+
+	// ConfigFile is the path of the config file holding the profiles.
+	// DefaultConfigFile is used when empty.
+	ConfigFile string
+
+	// OperationName is the optional custom prefix of the GraphQL operation
+	// names, see OperationNamePrefix.
+	OperationName string
+}
+
+// SkippedSource describes a credential source which ConnectDefault did not
+// use and why.
+type SkippedSource struct {
+	Source string
+	Reason string
+}
+
+// CredentialResolution reports which credential source ConnectDefault used
+// and why each earlier source was skipped.
+type CredentialResolution struct {
+	// Source describes the credential source used, empty when none could
+	// be used.
+	Source string
+
+	// Skipped lists the sources tried before Source, in order.
+	Skipped []SkippedSource
+}
+
+// String returns a human readable summary of the resolution.
+func (r *CredentialResolution) String() string {
+	var b strings.Builder
+	for _, skipped := range r.Skipped {
+		fmt.Fprintf(&b, "%s: skipped, %s; ", skipped.Source, skipped.Reason)
+	}
+	if r.Source != "" {
+		fmt.Fprintf(&b, "using %s", r.Source)
+	} else {
+		b.WriteString("no usable credentials found")
+	}
+	return b.String()
+}
+
+// ConnectDefault initializes a new API client from the first credential
+// source providing usable credentials, in the following order:
+//
+//  1. the explicit options,
+//  2. the environment variables rubrik_polaris_service_account (service
+//     account JSON), rubrik_polaris_service_account_file (service account
+//     file path) or rubrik_polaris_domain (or rubrik_polaris_url),
+//     rubrik_polaris_username and rubrik_polaris_password,
+//  3. the named profile of the config file, DefaultConfigFile by default,
+//  4. the DefaultServiceAccountFile.
+//
+// Only the sources holding no credentials at all are skipped. When a source
+// holds credentials which cannot be used, e.g. a service account file which
+// does not exist or incomplete user account credentials, an error is
+// returned instead of falling back to the next source, so that a client is
+// never silently connected to another account.
+//
+// The returned CredentialResolution reports the source used and why each
+// earlier source was skipped, it is returned along with the error when no
+// source could be used. options can be nil.
+func ConnectDefault(
+	options *ConnectOptions) (*Credentials, *CredentialResolution, error) {
+
+	if options == nil {
+		options = &ConnectOptions{}
+	}
+
+	resolution := &CredentialResolution{}
+
+	sources := []struct {
+		name    string
+		connect func() (*Credentials, string, error)
+	}{
+		{"explicit options", func() (*Credentials, string, error) {
+			return connectFromSettings(map[string]string{
+				"service_account_json": options.ServiceAccountJSON,
+				"service_account_file": options.ServiceAccountFile,
+				"url":                  options.BaseURL,
+				"domain":               options.PolarisDomain,
+				"username":             options.Username,
+				"password":             options.Password,
+			}, options.OperationName)
+		}},
+		{"environment variables", func() (*Credentials, string, error) {
+			return connectFromSettings(map[string]string{
+				"service_account_json": os.Getenv("rubrik_polaris_service_account"),
+				"service_account_file": os.Getenv("rubrik_polaris_service_account_file"),
+				"url":                  os.Getenv("rubrik_polaris_url"),
+				"domain":               os.Getenv("rubrik_polaris_domain"),
+				"username":             os.Getenv("rubrik_polaris_username"),
+				"password":             os.Getenv("rubrik_polaris_password"),
+			}, options.OperationName)
+		}},
+		{"config file profile", func() (*Credentials, string, error) {
+			return connectFromProfile(options)
+		}},
+		{"default service account file", func() (*Credentials, string, error) {
+			path, err := ExpandTildePath(DefaultServiceAccountFile)
+			if err != nil {
+				return nil, DefaultServiceAccountFile, err
+			}
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				return nil, DefaultServiceAccountFile, &noCredentialsError{
+					"the service account file does not exist"}
+			}
+			return connectFromSettings(map[string]string{
+				"service_account_file": DefaultServiceAccountFile,
+			}, options.OperationName)
+		}},
+	}
+
+	for _, source := range sources {
+		client, detail, err := source.connect()
+		name := source.name
+		if detail != "" {
+			name = fmt.Sprintf("%s (%s)", source.name, detail)
+		}
+		var noCredentials *noCredentialsError
+		if errors.As(err, &noCredentials) {
+			resolution.Skipped = append(resolution.Skipped,
+				SkippedSource{Source: name, Reason: err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, resolution, fmt.Errorf(
+				"invalid Polaris credentials in %s: %w", name, err)
+		}
+
+		resolution.Source = name
+		return client, resolution, nil
+	}
+
+	return nil, resolution, fmt.Errorf("no usable Polaris credentials: %s",
+		resolution)
+}
+
+// noCredentialsError is returned by the credential sources holding no
+// credentials at all, the only sources ConnectDefault skips.
+type noCredentialsError struct {
+	reason string
+}
+
+func (e *noCredentialsError) Error() string {
+	return e.reason
+}
+
+// errNoCredentials is returned by connectFromSettings when a source holds no
+// credentials at all.
+var errNoCredentials = &noCredentialsError{"no credentials given"}
+
+// connectFromSettings initializes a new API client from a set of settings
+// named like the keys of a config file profile. Service account credentials
+// take precedence over user account credentials. The url setting, when set,
+// overrides the API endpoint of the client. The returned string
+// describes which credentials were used, or were found to be invalid.
+func connectFromSettings(settings map[string]string,
+	operationName string) (*Credentials, string, error) {
+
+	client, detail, err := connectFromCredentialSettings(settings,
+		operationName)
+	if err != nil || settings["url"] == "" {
+		return client, detail, err
+	}
+
+	u, err := parseBaseURL(settings["url"])
+	if err != nil {
+		return nil, detail, err
+	}
+	client.BaseURL = u.String()
+	if client.AccessTokenUri == "" {
+		client.PolarisDomain = strings.Split(u.Hostname(), ".")[0]
+		client.Host = u.Host
+	}
+	return client, detail, nil
+}
+
+// connectFromCredentialSettings initializes a new API client from the
+// credentials of a set of settings.
+func connectFromCredentialSettings(settings map[string]string,
+	operationName string) (*Credentials, string, error) {
+
+	if settings["service_account_json"] != "" {
+		client, err := ConnectServiceAccountFromString(
+			settings["service_account_json"], operationName)
+		return client, "service account JSON", err
+	}
+
+	if file := settings["service_account_file"]; file != "" {
+		path, err := ExpandTildePath(file)
+		if err != nil {
+			return nil, file, err
+		}
+		if _, err := os.Stat(path); err != nil {
+			if os.IsNotExist(err) {
+				return nil, file, errors.New(
+					"the service account file does not exist")
+			}
+			return nil, file, err
+		}
+		client, err := ConnectServiceAccountFromFile(path, operationName)
+		return client, file, err
+	}
+
+	var missing []string
+	if settings["domain"] == "" && settings["url"] == "" {
+		missing = append(missing, "domain or url")
+	}
+	for _, key := range []string{"username", "password"} {
+		if settings[key] == "" {
+			missing = append(missing, key)
+		}
+	}
+	switch {
+	case len(missing) == 0:
+		return Connect(settings["domain"], settings["username"],
+			settings["password"], operationName), "user account", nil
+	case len(missing) == 3 && settings["url"] == "":
+		return nil, "", errNoCredentials
+	default:
+		return nil, "user account", fmt.Errorf(
+			"incomplete user account credentials, missing %s",
+			strings.Join(missing, ", "))
+	}
+}
+
+// connectFromProfile initializes a new API client from a named profile of
+// the config file. A missing config file or profile only means that there are
+// no credentials when neither was explicitly requested.
+func connectFromProfile(
+	options *ConnectOptions) (*Credentials, string, error) {
+
+	configFile := options.ConfigFile
+	if configFile == "" {
+		configFile = DefaultConfigFile
+	}
+
+	profile := options.Profile
+	if profile == "" {
+		profile = os.Getenv("rubrik_polaris_profile")
+	}
+	explicitProfile := profile != ""
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	detail := fmt.Sprintf("profile %q in %s", profile, configFile)
+
+	profiles, err := readConfigFile(configFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			if options.ConfigFile == "" && !explicitProfile {
+				return nil, detail, &noCredentialsError{
+					"the config file does not exist"}
+			}
+			return nil, detail, errors.New("the config file does not exist")
+		}
+		return nil, detail, err
+	}
+
+	settings, ok := profiles[profile]
+	if !ok {
+		if !explicitProfile {
+			return nil, detail, &noCredentialsError{
+				"the profile does not exist"}
+		}
+		return nil, detail, errors.New("the profile does not exist")
+	}
+
+	client, used, err := connectFromSettings(settings, options.OperationName)
+	if used != "" {
+		detail = fmt.Sprintf("%s, %s", detail, used)
+	}
+	return client, detail, err
+}
+
+// readConfigFile parses an INI style config file into its profiles:
+//
+//	[default]
+//	service_account_file = ~/.rubrik/polaris-service-account.json
+//
+//	[staging]
+//	url = https://staging.example.com
+//	username = user@example.com
+//	password = secret
+//
+// Lines starting with # or ; are comments.
+func readConfigFile(configFile string) (map[string]map[string]string, error) {
+	configFile, err := ExpandTildePath(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(configFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	profiles := map[string]map[string]string{}
+	var profile map[string]string
+
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") ||
+			strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if profiles[name] == nil {
+				profiles[name] = map[string]string{}
+			}
+			profile = profiles[name]
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || profile == nil {
+			return nil, fmt.Errorf("%s:%d: invalid line", configFile,
+				lineNumber)
+		}
+		profile[strings.TrimSpace(key)] = strings.Trim(
+			strings.TrimSpace(value), `"'`)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
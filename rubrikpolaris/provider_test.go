@@ -0,0 +1,151 @@
+package rubrikpolaris
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConnectDefault(t *testing.T) {
+	const serviceAccount = `{"client_id": "id", "client_secret": "secret",
+		"access_token_uri": "https://file.my.rubrik.com/api/client_token"}`
+
+	envVars := []string{
+		"rubrik_polaris_service_account",
+		"rubrik_polaris_service_account_file",
+		"rubrik_polaris_url",
+		"rubrik_polaris_domain",
+		"rubrik_polaris_username",
+		"rubrik_polaris_password",
+		"rubrik_polaris_profile",
+	}
+	userEnv := map[string]string{
+		"rubrik_polaris_domain":   "env",
+		"rubrik_polaris_username": "user",
+		"rubrik_polaris_password": "password",
+	}
+
+	tests := []struct {
+		name    string
+		options *ConnectOptions
+		env     map[string]string
+		config  string
+
+		// defaultFile is written to DefaultServiceAccountFile when set.
+		defaultFile string
+
+		host        string
+		errContains string
+	}{{
+		name: "EnvUserAccount",
+		env:  userEnv,
+		host: "env.my.rubrik.com",
+	}, {
+		name:    "ExplicitUserAccount",
+		options: &ConnectOptions{PolarisDomain: "explicit", Username: "user", Password: "password"},
+		env:     userEnv,
+		host:    "explicit.my.rubrik.com",
+	}, {
+		name:        "ExplicitMissingServiceAccountFile",
+		options:     &ConnectOptions{ServiceAccountFile: "/nonexistent.json"},
+		env:         userEnv,
+		errContains: "explicit options (/nonexistent.json)",
+	}, {
+		name:        "ExplicitInvalidServiceAccountJSON",
+		options:     &ConnectOptions{ServiceAccountJSON: `{"client_id": "id"}`},
+		env:         userEnv,
+		errContains: "missing JSON fields",
+	}, {
+		name:        "ExplicitIncompleteUserAccount",
+		options:     &ConnectOptions{PolarisDomain: "explicit", Username: "user"},
+		env:         userEnv,
+		errContains: "missing password",
+	}, {
+		name:        "EnvIncompleteUserAccount",
+		env:         map[string]string{"rubrik_polaris_domain": "env"},
+		defaultFile: serviceAccount,
+		errContains: "environment variables",
+	}, {
+		name:        "EnvMissingServiceAccountFile",
+		env:         map[string]string{"rubrik_polaris_service_account_file": "/nonexistent.json"},
+		defaultFile: serviceAccount,
+		errContains: "does not exist",
+	}, {
+		name:   "DefaultProfile",
+		config: "[default]\ndomain = profile\nusername = user\npassword = password\n",
+		host:   "profile.my.rubrik.com",
+	}, {
+		name:        "MissingExplicitProfile",
+		options:     &ConnectOptions{Profile: "staging"},
+		config:      "[default]\ndomain = profile\nusername = user\npassword = password\n",
+		defaultFile: serviceAccount,
+		errContains: "the profile does not exist",
+	}, {
+		name:        "MissingDefaultProfile",
+		config:      "[staging]\ndomain = profile\nusername = user\npassword = password\n",
+		defaultFile: serviceAccount,
+		host:        "file.my.rubrik.com",
+	}, {
+		name:        "DefaultServiceAccountFile",
+		defaultFile: serviceAccount,
+		host:        "file.my.rubrik.com",
+	}, {
+		name:        "InvalidDefaultServiceAccountFile",
+		defaultFile: `{"client_id": 1}`,
+		errContains: "client_id",
+	}, {
+		name:        "NoCredentials",
+		errContains: "no usable Polaris credentials",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			for _, name := range envVars {
+				t.Setenv(name, "")
+			}
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+			if err := os.Mkdir(filepath.Join(home, ".rubrik"), 0700); err != nil {
+				t.Fatal(err)
+			}
+			if test.config != "" {
+				writeFile(t, filepath.Join(home, ".rubrik", "config"), test.config)
+			}
+			if test.defaultFile != "" {
+				writeFile(t, filepath.Join(home, ".rubrik", "polaris-service-account.json"),
+					test.defaultFile)
+			}
+
+			client, resolution, err := ConnectDefault(test.options)
+			if test.errContains != "" {
+				if err == nil {
+					t.Fatalf("expected an error, got a client for %s (%s)",
+						client.Host, resolution)
+				}
+				if !strings.Contains(err.Error(), test.errContains) {
+					t.Fatalf("error %q does not contain %q", err, test.errContains)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if client.Host != test.host {
+				t.Fatalf("got a client for %s, want %s (%s)", client.Host,
+					test.host, resolution)
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -1,171 +1,222 @@
 package rubrikpolaris
 
 import (
+	"context"
+	"errors"
 	"time"
-
-	"github.com/mitchellh/mapstructure"
 )
 
-// RadarEventsLast24Hours returns the number of Radar events that occured in the last 24 hours
-func (c *Credentials) GetRadarEventsLast24Hours(timeout ...int) (float64, error) {
+// radarEventCount is the data of the RadarEventCount.graphql query.
+type radarEventCount struct {
+	ActivitySeriesConnection *struct {
+		Count *int `json:"count"`
+	} `json:"activitySeriesConnection"`
+}
 
-	httpTimeout := httpTimeout(timeout)
+// GetRadarEventCount returns the number of Radar events last updated after
+// since and before until. A zero until counts the events up to now.
+func (c *Credentials) GetRadarEventCount(since, until time.Time, timeout ...int) (int, error) {
+	return c.GetRadarEventCountContext(context.Background(), since, until, timeout...)
+}
 
-	query := c.readQueryFile("RadarEventsPerTimePeriod.graphql")
+// GetRadarEventCountContext is like GetRadarEventCount but takes a context
+// which cancels the underlying requests when done.
+func (c *Credentials) GetRadarEventCountContext(ctx context.Context, since, until time.Time, timeout ...int) (_ int, err error) {
 
-	variables := map[string]interface{}{}
-	variables["timeAgo"] = time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339)
+	ctx, span := c.startSpan(ctx, "GetRadarEventCount")
+	defer func() { endSpan(span, err) }()
+
+	httpTimeout := httpTimeout(timeout)
 
-	radar, err := c.QueryWithVariables(query, variables, httpTimeout)
-	if err != nil {
+	sinceTime := since.UTC().Format(time.RFC3339)
+	variables := &RadarEventCountVariables{Since: &sinceTime}
+	if !until.IsZero() {
+		untilTime := until.UTC().Format(time.RFC3339)
+		variables.Until = &untilTime
+	}
+
+	// The count is decoded into a pointer, so that a response missing it is
+	// reported instead of counted as no events
+	radar, err := doOperation[radarEventCount](ctx, c, "RadarEventCount.graphql", variables, false, httpTimeout)
+	if err != nil && !IsPartialData(err) {
 		return 0, err
 	}
-	return radar.(map[string]interface{})["data"].(map[string]interface{})["activitySeriesConnection"].(map[string]interface{})["count"].(float64), nil
+	if radar.ActivitySeriesConnection == nil || radar.ActivitySeriesConnection.Count == nil {
+		if err != nil {
+			return 0, err
+		}
+		return 0, &DecodeError{
+			Type: "radarEventCount",
+			Err:  errors.New("the response does not contain a count"),
+		}
+	}
+	return *radar.ActivitySeriesConnection.Count, err
 
 }
 
-// RadarEventsLast30Days returns the number of Radar events that occured in the last 30 days
-func (c *Credentials) GetRadarEventsLast30Days(timeout ...int) (float64, error) {
-
-	httpTimeout := httpTimeout(timeout)
-
-	query := c.readQueryFile("RadarEventsPerTimePeriod.graphql")
+// RadarEventsLast24Hours returns the number of Radar events that occured in the last 24 hours
+func (c *Credentials) GetRadarEventsLast24Hours(timeout ...int) (float64, error) {
+	return c.GetRadarEventsLast24HoursContext(context.Background(), timeout...)
+}
 
-	variables := map[string]interface{}{}
-	variables["timeAgo"] = time.Now().Add(-720 * time.Hour).UTC().Format(time.RFC3339)
+// GetRadarEventsLast24HoursContext is like GetRadarEventsLast24Hours but takes
+// a context which cancels the underlying requests when done.
+func (c *Credentials) GetRadarEventsLast24HoursContext(ctx context.Context, timeout ...int) (float64, error) {
+	return c.radarEventsSince(ctx, 24*time.Hour, timeout...)
+}
 
-	radar, err := c.QueryWithVariables(query, variables, httpTimeout)
-	if err != nil {
-		return 0, err
-	}
-	return radar.(map[string]interface{})["data"].(map[string]interface{})["activitySeriesConnection"].(map[string]interface{})["count"].(float64), nil
+// RadarEventsLast30Days returns the number of Radar events that occured in the last 30 days
+func (c *Credentials) GetRadarEventsLast30Days(timeout ...int) (float64, error) {
+	return c.GetRadarEventsLast30DaysContext(context.Background(), timeout...)
+}
 
+// GetRadarEventsLast30DaysContext is like GetRadarEventsLast30Days but takes a
+// context which cancels the underlying requests when done.
+func (c *Credentials) GetRadarEventsLast30DaysContext(ctx context.Context, timeout ...int) (float64, error) {
+	return c.radarEventsSince(ctx, 720*time.Hour, timeout...)
 }
 
 // RadarEventsLastYear returns the number of Radar events that occured in the last year
 func (c *Credentials) GetRadarEventsLastYear(timeout ...int) (float64, error) {
+	return c.GetRadarEventsLastYearContext(context.Background(), timeout...)
+}
 
-	httpTimeout := httpTimeout(timeout)
-
-	query := c.readQueryFile("RadarEventsPerTimePeriod.graphql")
-
-	variables := map[string]interface{}{}
-	variables["timeAgo"] = time.Now().Add(-8760 * time.Hour).UTC().Format(time.RFC3339)
-
-	radar, err := c.QueryWithVariables(query, variables, httpTimeout)
-	if err != nil {
-		return 0, err
-	}
-	return radar.(map[string]interface{})["data"].(map[string]interface{})["activitySeriesConnection"].(map[string]interface{})["count"].(float64), nil
+// GetRadarEventsLastYearContext is like GetRadarEventsLastYear but takes a
+// context which cancels the underlying requests when done.
+func (c *Credentials) GetRadarEventsLastYearContext(ctx context.Context, timeout ...int) (float64, error) {
+	return c.radarEventsSince(ctx, 8760*time.Hour, timeout...)
+}
 
+// radarEventsSince returns the number of Radar events of the given last
+// period.
+func (c *Credentials) radarEventsSince(ctx context.Context, period time.Duration, timeout ...int) (float64, error) {
+	count, err := c.GetRadarEventCountContext(ctx, time.Now().Add(-period), time.Time{}, timeout...)
+	return float64(count), err
 }
 
 // GetRadarEnabledClusters returns the name of each Rubrik cluster with Radar enabled map to its ID value.
 func (c *Credentials) GetRadarEnabledClusters(timeout ...int) (map[string]string, error) {
+	return c.GetRadarEnabledClustersContext(context.Background(), timeout...)
+}
 
-	httpTimeout := httpTimeout(timeout)
+// GetRadarEnabledClustersContext is like GetRadarEnabledClusters but takes a
+// context which cancels the underlying requests when done.
+func (c *Credentials) GetRadarEnabledClustersContext(ctx context.Context, timeout ...int) (_ map[string]string, err error) {
 
-	query := c.readQueryFile("RadarEnabledClusters.graphql")
+	ctx, span := c.startSpan(ctx, "GetRadarEnabledClusters")
+	defer func() { endSpan(span, err) }()
 
-	radarEnabledClustersQuery, err := c.Query(query, httpTimeout)
-	if err != nil {
-		return nil, err
-	}
+	httpTimeout := httpTimeout(timeout)
 
-	// Convert the API Response (map[string]interface{}) to a struct
-	var apiResponse RadarEnabledClusters
-	mapErr := mapstructure.Decode(radarEnabledClustersQuery, &apiResponse)
-	if mapErr != nil {
-		return nil, mapErr
+	radarEnabledClusters, err := c.RadarEnabledClustersContext(ctx, httpTimeout)
+	if err != nil && !IsPartialData(err) {
+		return nil, err
 	}
 
 	enabledClusters := make(map[string]string)
 
-	for _, cluster := range apiResponse.Data.RadarClusterConnection.Nodes {
+	for _, cluster := range radarEnabledClusters.RadarClusterConnection.Nodes {
 		if cluster.LambdaConfig != nil {
-			enabledClusters[cluster.Name] = cluster.LambdaConfig.(map[string]interface{})["clusterId"].(string)
+			enabledClusters[cluster.Name] = cluster.LambdaConfig.ClusterID
 		}
 
 	}
 
-	return enabledClusters, nil
+	return enabledClusters, err
 
 }
 
 // GetRadarEvents returns the name of each Rubrik cluster with Radar enabled map to its ID value.
 func (c *Credentials) GetRadarEvents(timeAgo string, timeout ...int) (*RadarEvent, error) {
+	return c.GetRadarEventsContext(context.Background(), timeAgo, timeout...)
+}
 
-	httpTimeout := httpTimeout(timeout)
+// GetRadarEventsContext is like GetRadarEvents but takes a context which
+// cancels the underlying requests when done.
+func (c *Credentials) GetRadarEventsContext(ctx context.Context, timeAgo string, timeout ...int) (_ *RadarEvent, err error) {
 
-	queryString := c.readQueryFile("RadarEventsPerTimePeriod.graphql")
+	ctx, span := c.startSpan(ctx, "GetRadarEvents")
+	defer func() { endSpan(span, err) }()
 
-	variables := map[string]interface{}{}
-	variables["timeAgo"] = timeAgo
+	httpTimeout := httpTimeout(timeout)
 
-	radarEvents, err := c.QueryWithVariables(queryString, variables, httpTimeout)
-	if err != nil {
+	radarEvents, err := c.RadarEventsPerTimePeriodContext(ctx, &RadarEventsPerTimePeriodVariables{
+		TimeAgo: &timeAgo,
+	}, httpTimeout)
+	if err != nil && !IsPartialData(err) {
 		return nil, err
 	}
 
-	// Convert the API Response (map[string]interface{}) to a struct
+	operation := c.fileOperationName("RadarEventsPerTimePeriod.graphql")
+	c.metrics().ObservePage(operation)
+	c.metrics().ObserveEvents(operation, len(radarEvents.ActivitySeriesConnection.Edges))
+
 	var apiResponse RadarEvent
-	mapErr := mapstructure.Decode(radarEvents, &apiResponse)
-	if mapErr != nil {
-		return nil, mapErr
+	if err := convertResponse(radarEvents, &apiResponse.Data); err != nil {
+		return nil, err
 	}
-
-	return &apiResponse, nil
+	return &apiResponse, err
 
 }
 
 // GetRadarAndSonarEvents returns all Radar and Sonar events for the specified time period
 func (c *Credentials) GetRadarAndSonarEvents(timeAgo string, timeout ...int) (*RadarEvent, error) {
+	return c.GetRadarAndSonarEventsContext(context.Background(), timeAgo, timeout...)
+}
 
-	httpTimeout := httpTimeout(timeout)
+// GetRadarAndSonarEventsContext is like GetRadarAndSonarEvents but takes a
+// context which cancels the underlying requests when done.
+func (c *Credentials) GetRadarAndSonarEventsContext(ctx context.Context, timeAgo string, timeout ...int) (_ *RadarEvent, err error) {
 
-	queryString := c.readQueryFile("RadarSonarEventsPerTimePeriod.graphql")
+	ctx, span := c.startSpan(ctx, "GetRadarAndSonarEvents")
+	defer func() { endSpan(span, err) }()
 
-	variables := map[string]interface{}{}
-	variables["timeAgo"] = timeAgo
+	httpTimeout := httpTimeout(timeout)
 
-	radarEvents, err := c.QueryWithVariables(queryString, variables, httpTimeout)
-	if err != nil {
+	radarEvents, err := c.RadarSonarEventsPerTimePeriodContext(ctx, &RadarSonarEventsPerTimePeriodVariables{
+		TimeAgo: &timeAgo,
+	}, httpTimeout)
+	if err != nil && !IsPartialData(err) {
 		return nil, err
 	}
 
-	// Convert the API Response (map[string]interface{}) to a struct
+	operation := c.fileOperationName("RadarSonarEventsPerTimePeriod.graphql")
+	c.metrics().ObservePage(operation)
+	c.metrics().ObserveEvents(operation, len(radarEvents.ActivitySeriesConnection.Edges))
+
 	var apiResponse RadarEvent
-	mapErr := mapstructure.Decode(radarEvents, &apiResponse)
-	if mapErr != nil {
-		return nil, mapErr
+	if err := convertResponse(radarEvents, &apiResponse.Data); err != nil {
+		return nil, err
 	}
-
-	return &apiResponse, nil
+	return &apiResponse, err
 
 }
 
 // GetRadarAndSonarEvents returns all Radar and Sonar events for the specified time period
 func (c *Credentials) EnableRadar(clusterId string, timeout ...int) (*EnableRadar, error) {
+	return c.EnableRadarContext(context.Background(), clusterId, timeout...)
+}
 
-	httpTimeout := httpTimeout(timeout)
+// EnableRadarContext is like EnableRadar but takes a context which cancels the
+// underlying requests when done.
+func (c *Credentials) EnableRadarContext(ctx context.Context, clusterId string, timeout ...int) (_ *EnableRadar, err error) {
 
-	queryString := c.readQueryFile("EnableRadar.graphql")
+	ctx, span := c.startSpan(ctx, "EnableRadar")
+	defer func() { endSpan(span, err) }()
 
-	variables := map[string]interface{}{}
-	variables["clusterId"] = clusterId
+	httpTimeout := httpTimeout(timeout)
 
-	enable, err := c.MutationWithVariables(queryString, variables, httpTimeout)
-	if err != nil {
+	enable, err := c.ToggleRadarPrefsMutationContext(ctx, &ToggleRadarPrefsMutationVariables{
+		ClusterID: clusterId,
+	}, httpTimeout)
+	if err != nil && !IsPartialData(err) {
 		return nil, err
 	}
 
-	// Convert the API Response (map[string]interface{}) to a struct
 	var apiResponse EnableRadar
-	mapErr := mapstructure.Decode(enable, &apiResponse)
-	if mapErr != nil {
-		return nil, mapErr
+	if err := convertResponse(enable, &apiResponse.Data); err != nil {
+		return nil, err
 	}
-
-	return &apiResponse, nil
+	return &apiResponse, err
 
 }
@@ -0,0 +1,78 @@
+package rubrikpolaris
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetRadarEnabledClusters(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"radarClusterConnection": {"nodes": [
+			{"name": "enabled", "lambdaConfig": {"clusterId": "id-1", "enableAutomaticFmdUpload": true}},
+			{"name": "disabled", "lambdaConfig": null}
+		]}}}`))
+	})
+
+	clusters, err := c.GetRadarEnabledClusters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"enabled": "id-1"}; !reflect.DeepEqual(clusters, want) {
+		t.Fatalf("got %v, want %v", clusters, want)
+	}
+}
+
+func TestGetRadarEventCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		count int
+		err   bool
+	}{{
+		name:  "Count",
+		body:  `{"data": {"activitySeriesConnection": {"count": 3}}}`,
+		count: 3,
+	}, {
+		name: "NullData",
+		body: `{"data": null}`,
+		err:  true,
+	}, {
+		name: "EmptyData",
+		body: `{"data": {}}`,
+		err:  true,
+	}, {
+		name: "MissingCount",
+		body: `{"data": {"activitySeriesConnection": {}}}`,
+		err:  true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(test.body))
+			})
+
+			count, err := c.GetRadarEventCount(time.Now().Add(-time.Hour), time.Time{})
+			var decodeErr *DecodeError
+			if test.err != errors.As(err, &decodeErr) {
+				t.Fatalf("got error %v, want a DecodeError: %t", err, test.err)
+			}
+			if count != test.count {
+				t.Fatalf("got count %d, want %d", count, test.count)
+			}
+
+			last24Hours, err := c.GetRadarEventsLast24Hours()
+			if test.err != errors.As(err, &decodeErr) {
+				t.Fatalf("got error %v from GetRadarEventsLast24Hours, want a DecodeError: %t",
+					err, test.err)
+			}
+			if last24Hours != float64(test.count) {
+				t.Fatalf("got count %v from GetRadarEventsLast24Hours, want %d",
+					last24Hours, test.count)
+			}
+		})
+	}
+}
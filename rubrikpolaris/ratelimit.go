@@ -0,0 +1,135 @@
+package rubrikpolaris
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimit configures a token bucket rate limiter.
+type RateLimit struct {
+	// Rate is the sustained number of requests per second. The limiter is
+	// disabled when it is zero or negative.
+	Rate float64
+
+	// Burst is the number of requests which can be sent at once. It
+	// defaults to the Rate rounded up, and at least 1.
+	Burst int
+}
+
+// tokenBucket is a goroutine-safe token bucket rate limiter.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newTokenBucket returns a full token bucket for limit, nil when the limit
+// is disabled.
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	if limit.Rate <= 0 {
+		return nil
+	}
+
+	burst := float64(limit.Burst)
+	if burst <= 0 {
+		burst = math.Max(1, math.Ceil(limit.Rate))
+	}
+
+	return &tokenBucket{
+		rate:   limit.Rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a request is allowed or ctx is done. Requests are
+// allowed in the order wait is called.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = math.Min(b.burst,
+		b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	// Reserve a token, going into debt when none is available
+	b.tokens--
+	if b.tokens >= 0 {
+		b.mu.Unlock()
+		return nil
+	}
+	delay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mu.Unlock()
+
+	if err := sleepContext(ctx, delay); err != nil {
+		// Give the reserved token back
+		b.refund()
+		return err
+	}
+	return nil
+}
+
+// refund gives back a token taken by wait for a request which is not sent.
+func (b *tokenBucket) refund() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// waitRateLimit blocks until the rate limiters of the client allow the given
+// request or ctx is done. Every request of the client is subject to
+// RateLimit, GraphQL requests are also subject to the OperationRateLimits
+// entry of their operation.
+func (c *Credentials) waitRateLimit(
+	ctx context.Context,
+	callType string,
+	payload interface{}) error {
+
+	if c.RateLimit == nil && len(c.OperationRateLimits) == 0 {
+		return nil
+	}
+
+	c.rateLimitMu.Lock()
+	if c.rateLimiter == nil && c.RateLimit != nil {
+		c.rateLimiter = newTokenBucket(*c.RateLimit)
+	}
+	limiter := c.rateLimiter
+
+	var operationLimiter *tokenBucket
+	if req, ok := payload.(*GraphQLRequest); ok && len(c.OperationRateLimits) > 0 {
+		name := staticOperationName(req)
+		if limit, ok := c.OperationRateLimits[name]; ok {
+			if c.operationLimiters == nil {
+				c.operationLimiters = map[string]*tokenBucket{}
+			}
+			if _, ok := c.operationLimiters[name]; !ok {
+				c.operationLimiters[name] = newTokenBucket(limit)
+			}
+			operationLimiter = c.operationLimiters[name]
+		}
+	}
+	c.rateLimitMu.Unlock()
+
+	if err := operationLimiter.wait(ctx); err != nil {
+		return err
+	}
+	if err := limiter.wait(ctx); err != nil {
+		// The request is not sent, so it does not count against the limit
+		// of its operation either
+		operationLimiter.refund()
+		return err
+	}
+	return nil
+}
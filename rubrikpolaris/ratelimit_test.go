@@ -0,0 +1,128 @@
+package rubrikpolaris
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// bucketTokens returns the number of tokens left in b.
+func bucketTokens(b *tokenBucket) float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tokens
+}
+
+func TestTokenBucketBurstAndRefill(t *testing.T) {
+	b := newTokenBucket(RateLimit{Rate: 20, Burst: 3})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := b.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 45*time.Millisecond {
+		t.Fatalf("the burst took %v, want no wait", elapsed)
+	}
+
+	// The bucket is empty, the next requests wait for a token each
+	start = time.Now()
+	for i := 0; i < 2; i++ {
+		if err := b.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("2 requests over the burst took %v, want 100ms", elapsed)
+	}
+}
+
+func TestTokenBucketDefaultBurst(t *testing.T) {
+	if b := newTokenBucket(RateLimit{Rate: 2.5}); b.burst != 3 {
+		t.Errorf("got a burst of %v, want 3", b.burst)
+	}
+	if b := newTokenBucket(RateLimit{Rate: 0.5}); b.burst != 1 {
+		t.Errorf("got a burst of %v, want 1", b.burst)
+	}
+	if b := newTokenBucket(RateLimit{}); b != nil {
+		t.Errorf("got %+v, want no limiter for a zero rate", b)
+	}
+}
+
+func TestTokenBucketCanceledWaitRefund(t *testing.T) {
+	b := newTokenBucket(RateLimit{Rate: 1, Burst: 1})
+	if err := b.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := b.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+
+	// The canceled request does not keep its reserved token
+	if tokens := bucketTokens(b); tokens < -0.1 {
+		t.Fatalf("got %v tokens, want the reserved token refunded", tokens)
+	}
+}
+
+func TestOperationRateLimits(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"ok": true}}`))
+	})
+	c.OperationName = "Test"
+	c.OperationRateLimits = map[string]RateLimit{
+		"Limited": {Rate: 20, Burst: 1},
+	}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := c.Query("query Other { ok }"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 80*time.Millisecond {
+		t.Fatalf("3 requests of an unlimited operation took %v, want no wait", elapsed)
+	}
+
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := c.Query("query Limited { ok }"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Fatalf("3 requests of a limited operation took %v, want 100ms", elapsed)
+	}
+}
+
+func TestRateLimitCanceledWaitRefundsOperation(t *testing.T) {
+	c := &Credentials{
+		RateLimit: &RateLimit{Rate: 1, Burst: 1},
+		OperationRateLimits: map[string]RateLimit{
+			"Limited": {Rate: 1, Burst: 2},
+		},
+	}
+	req := &GraphQLRequest{Query: "query Limited { ok }"}
+
+	if err := c.waitRateLimit(context.Background(), CallTypeGraphQL, req); err != nil {
+		t.Fatal(err)
+	}
+
+	// The operation limit allows the request, the client limit does not
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := c.waitRateLimit(ctx, CallTypeGraphQL, req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+
+	if tokens := bucketTokens(c.operationLimiters["Limited"]); tokens < 0.9 {
+		t.Fatalf("got %v operation tokens, want the token of the canceled request refunded",
+			tokens)
+	}
+}
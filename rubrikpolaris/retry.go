@@ -0,0 +1,183 @@
+package rubrikpolaris
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Defaults of the RetryPolicy settings.
+	DefaultRetryMaxAttempts = 4
+	DefaultRetryBaseDelay   = time.Second
+	DefaultRetryMaxDelay    = 30 * time.Second
+	DefaultRetryJitter      = 0.5
+)
+
+// RetryPolicy configures how GraphQL requests failing with a transient error
+// are retried. Transient errors are 429, 502, 503 and 504 HTTP responses,
+// connection resets and request timeouts. Attempt n waits BaseDelay * 2^(n-1),
+// capped at MaxDelay and randomized by Jitter, or the delay requested by the
+// Retry-After header of the response when that is longer.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first
+	// one. DefaultRetryMaxAttempts is used when zero.
+	MaxAttempts int
+
+	// BaseDelay and MaxDelay bound the delay between attempts.
+	// DefaultRetryBaseDelay and DefaultRetryMaxDelay are used when zero.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// Jitter is the fraction, between 0 and 1, of each delay which is
+	// randomized. DefaultRetryJitter is used when zero, use a negative
+	// value to disable the jitter.
+	Jitter float64
+
+	// RetryMutations allows mutations to be retried. Mutations are not
+	// idempotent in general, so only queries are retried by default.
+	RetryMutations bool
+
+	// OnRetry, when set, is called before waiting for each retry.
+	OnRetry func(RetryEvent)
+}
+
+// RetryEvent describes a retry about to happen.
+type RetryEvent struct {
+	// Attempt is the number of the attempt which failed, starting at 1.
+	Attempt int
+
+	// OperationName is the GraphQL operation name sent to the API.
+	OperationName string
+
+	// Delay is how long the client waits before the next attempt.
+	Delay time.Duration
+
+	// Err is the error of the failed attempt.
+	Err error
+}
+
+// DefaultRetryPolicy returns a RetryPolicy using the default settings.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: DefaultRetryMaxAttempts,
+		BaseDelay:   DefaultRetryBaseDelay,
+		MaxDelay:    DefaultRetryMaxDelay,
+		Jitter:      DefaultRetryJitter,
+	}
+}
+
+// isTransientStatus returns true for the HTTP status codes worth retrying.
+func isTransientStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses the value of a Retry-After header, either a number
+// of seconds or an HTTP date. Zero is returned when the value is invalid.
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// isTransientError returns true when err is worth retrying. Errors caused by
+// ctx being done are never retried.
+func isTransientError(ctx context.Context, err error) bool {
+	return ctx.Err() == nil && IsRetryable(err)
+}
+
+// delay returns how long to wait after the given failed attempt.
+func (p *RetryPolicy) delay(attempt int, err error) time.Duration {
+	base := p.BaseDelay
+	if base <= 0 {
+		base = DefaultRetryBaseDelay
+	}
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryMaxDelay
+	}
+	jitter := p.Jitter
+	if jitter == 0 {
+		jitter = DefaultRetryJitter
+	}
+
+	delay := time.Duration(math.Min(
+		float64(base)*math.Pow(2, float64(attempt-1)), float64(maxDelay)))
+	if jitter > 0 {
+		delay -= time.Duration(math.Min(jitter, 1) * rand.Float64() *
+			float64(delay))
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) && httpErr.RetryAfter > delay {
+		delay = httpErr.RetryAfter
+	}
+
+	return delay
+}
+
+// commonAPIWithRetry sends a GraphQL request with commonAPIBody, retrying
+// transient failures according to the RetryPolicy of the client.
+func (c *Credentials) commonAPIWithRetry(
+	ctx context.Context,
+	req *GraphQLRequest,
+	timeout int) ([]byte, error) {
+
+	policy := c.RetryPolicy
+	if policy == nil || (isMutation(req) && !policy.RetryMutations) {
+		return c.commonAPIBody(ctx, CallTypeGraphQL, req, timeout)
+	}
+
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultRetryMaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		body, err := c.commonAPIBody(ctx, CallTypeGraphQL, req, timeout)
+		if attempt >= maxAttempts || !isTransientError(ctx, err) {
+			return body, err
+		}
+
+		delay := policy.delay(attempt, err)
+		operationName := c.operationName(req)
+		c.metrics().ObserveRetry(operationName)
+		if policy.OnRetry != nil {
+			policy.OnRetry(RetryEvent{
+				Attempt:       attempt,
+				OperationName: operationName,
+				Delay:         delay,
+				Err:           err,
+			})
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package rubrikpolaris
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newRetryTestClient returns a client with policy whose test server answers
+// the n-th request, starting at 1, with handler.
+func newRetryTestClient(t *testing.T, policy *RetryPolicy,
+	handler func(w http.ResponseWriter, n int)) (*Credentials, func() int) {
+
+	var mu sync.Mutex
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		n := requests
+		mu.Unlock()
+		handler(w, n)
+	})
+	c.RetryPolicy = policy
+
+	return c, func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func TestRetryTransientFailures(t *testing.T) {
+	var events []RetryEvent
+	c, requests := newRetryTestClient(t, &RetryPolicy{
+		BaseDelay: time.Millisecond,
+		MaxDelay:  10 * time.Millisecond,
+		Jitter:    -1,
+		OnRetry:   func(event RetryEvent) { events = append(events, event) },
+	}, func(w http.ResponseWriter, n int) {
+		if n <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"data": {"ok": true}}`))
+	})
+	c.OperationName = "Test"
+
+	if _, err := c.Query("query Retry { ok }"); err != nil {
+		t.Fatal(err)
+	}
+	if n := requests(); n != 3 {
+		t.Fatalf("got %d requests, want 3", n)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("got %d retry events, want 2", len(events))
+	}
+	for i, event := range events {
+		want := time.Duration(1<<i) * time.Millisecond
+		if event.Attempt != i+1 || event.Delay != want ||
+			event.OperationName != "TestRetry" {
+			t.Errorf("got retry event %+v, want attempt %d with delay %v",
+				event, i+1, want)
+		}
+		var httpErr *HTTPError
+		if !errors.As(event.Err, &httpErr) ||
+			httpErr.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("got retry event error %v, want a 503 HTTPError", event.Err)
+		}
+	}
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	c, requests := newRetryTestClient(t, &RetryPolicy{
+		MaxAttempts: 2,
+		BaseDelay:   time.Millisecond,
+	}, func(w http.ResponseWriter, n int) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, err := c.Query("query Retry { ok }")
+	if !IsRetryable(err) {
+		t.Fatalf("got %v, want the retryable error of the last attempt", err)
+	}
+	if n := requests(); n != 2 {
+		t.Fatalf("got %d requests, want 2", n)
+	}
+}
+
+func TestRetryAfterLongerThanMaxDelay(t *testing.T) {
+	var delay time.Duration
+	c, requests := newRetryTestClient(t, &RetryPolicy{
+		BaseDelay: time.Millisecond,
+		MaxDelay:  10 * time.Millisecond,
+		OnRetry:   func(event RetryEvent) { delay = event.Delay },
+	}, func(w http.ResponseWriter, n int) {
+		if n == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"data": {"ok": true}}`))
+	})
+
+	start := time.Now()
+	if _, err := c.Query("query Retry { ok }"); err != nil {
+		t.Fatal(err)
+	}
+	if n := requests(); n != 2 {
+		t.Fatalf("got %d requests, want 2", n)
+	}
+	if delay != time.Second {
+		t.Fatalf("got a delay of %v, want the Retry-After delay of 1s", delay)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("the retry was sent after %v, before the Retry-After delay", elapsed)
+	}
+}
+
+func TestRetryMutations(t *testing.T) {
+	for _, retryMutations := range []bool{false, true} {
+		c, requests := newRetryTestClient(t, &RetryPolicy{
+			BaseDelay:      time.Millisecond,
+			RetryMutations: retryMutations,
+		}, func(w http.ResponseWriter, n int) {
+			if n == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"data": {"ok": true}}`))
+		})
+
+		_, err := c.Query("mutation Retry { ok }")
+		if retryMutations {
+			if err != nil || requests() != 2 {
+				t.Fatalf("got %v after %d requests, want the mutation retried",
+					err, requests())
+			}
+			continue
+		}
+		if !IsRetryable(err) || requests() != 1 {
+			t.Fatalf("got %v after %d requests, want the mutation not retried",
+				err, requests())
+		}
+	}
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, requests := newRetryTestClient(t, &RetryPolicy{
+		BaseDelay: time.Minute,
+		MaxDelay:  time.Minute,
+		OnRetry: func(event RetryEvent) {
+			time.AfterFunc(10*time.Millisecond, cancel)
+		},
+	}, func(w http.ResponseWriter, n int) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	start := time.Now()
+	_, err := c.QueryContext(ctx, "query Retry { ok }")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Fatalf("the wait was not interrupted, it returned after %v", elapsed)
+	}
+	if n := requests(); n != 1 {
+		t.Fatalf("got %d requests, want 1", n)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := &RetryPolicy{
+		BaseDelay: 100 * time.Millisecond,
+		MaxDelay:  time.Second,
+		Jitter:    -1,
+	}
+	for attempt, want := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		if got := policy.delay(attempt, nil); got != want {
+			t.Errorf("attempt %d: got %v, want %v", attempt, got, want)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := policy.delay(2, nil); got < 100*time.Millisecond || got > 200*time.Millisecond {
+			t.Fatalf("got a jittered delay of %v, want one between 100ms and 200ms", got)
+		}
+	}
+
+	err := &HTTPError{StatusCode: http.StatusServiceUnavailable, RetryAfter: time.Minute}
+	if got := policy.delay(1, err); got != time.Minute {
+		t.Errorf("got %v, want the Retry-After delay of 1m0s", got)
+	}
+}
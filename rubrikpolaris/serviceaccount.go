@@ -0,0 +1,111 @@
+package rubrikpolaris
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// serviceAccount holds a Polaris service account configuration. Fields of
+// the service account file not listed here are ignored.
+type serviceAccount struct {
+	// Polaris Client ID.
+	ClientId string `json:"client_id"`
+
+	// Polaris Client Secret.
+	ClientSecret string `json:"client_secret"`
+
+	// Polaris account url.
+	AccessTokenUri string `json:"access_token_uri"`
+
+	// tokenURL is the parsed AccessTokenUri.
+	tokenURL *url.URL
+}
+
+// parseServiceAccount parses and validates the content of a service account
+// file. The returned errors name the offending field.
+func parseServiceAccount(buf []byte) (*serviceAccount, error) {
+	var account serviceAccount
+	if err := json.Unmarshal(buf, &account); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		var syntaxErr *json.SyntaxError
+		switch {
+		case errors.As(err, &typeErr) && typeErr.Field != "":
+			return nil, fmt.Errorf(
+				"invalid service account JSON: field %s must be a string, "+
+					"got %s", typeErr.Field, typeErr.Value)
+		case errors.As(err, &typeErr):
+			return nil, fmt.Errorf(
+				"invalid service account JSON: expected an object, got %s",
+				typeErr.Value)
+		case errors.As(err, &syntaxErr):
+			return nil, fmt.Errorf(
+				"invalid service account JSON: %v at offset %d", err,
+				syntaxErr.Offset)
+		default:
+			return nil, fmt.Errorf("invalid service account JSON: %v", err)
+		}
+	}
+
+	account.ClientId = strings.TrimSpace(account.ClientId)
+	account.ClientSecret = strings.TrimSpace(account.ClientSecret)
+	account.AccessTokenUri = strings.TrimSpace(account.AccessTokenUri)
+
+	var missing []string
+	if account.AccessTokenUri == "" {
+		missing = append(missing, "access_token_uri")
+	}
+	if account.ClientId == "" {
+		missing = append(missing, "client_id")
+	}
+	if account.ClientSecret == "" {
+		missing = append(missing, "client_secret")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing JSON fields: %v", missing)
+	}
+
+	tokenURL, err := parseAccessTokenURI(account.AccessTokenUri)
+	if err != nil {
+		return nil, err
+	}
+	account.tokenURL = tokenURL
+
+	return &account, nil
+}
+
+// parseAccessTokenURI parses and validates the access_token_uri field of a
+// service account file.
+func parseAccessTokenURI(uri string) (*url.URL, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		// The *url.Error holds the whole URI, including its password
+		return nil, fmt.Errorf("invalid access_token_uri field: %v", errors.Unwrap(err))
+	}
+
+	switch {
+	case u.Scheme == "":
+		return nil, fmt.Errorf(
+			"invalid access_token_uri field %q: missing scheme, expected "+
+				"an absolute https URL", uri)
+	case u.Scheme != "https" && u.Scheme != "http":
+		return nil, fmt.Errorf(
+			"invalid access_token_uri field %q: unsupported scheme %q",
+			uri, u.Scheme)
+	case u.Opaque != "":
+		return nil, fmt.Errorf(
+			"invalid access_token_uri field %q: expected scheme://host/path",
+			uri)
+	case u.Hostname() == "":
+		return nil, fmt.Errorf(
+			"invalid access_token_uri field %q: missing host", uri)
+	case u.User != nil:
+		return nil, fmt.Errorf(
+			"invalid access_token_uri field %q: user information is not "+
+				"allowed", u.Redacted())
+	}
+
+	return u, nil
+}
@@ -0,0 +1,116 @@
+package rubrikpolaris
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultTokenLifetime is the lifetime assumed for a token when neither
+	// the token response nor the token itself carries an expiry.
+	defaultTokenLifetime = 24 * time.Hour
+
+	// DefaultTokenRefreshMargin is how long before its expiry a token is
+	// refreshed when Credentials.TokenRefreshMargin is not set.
+	DefaultTokenRefreshMargin = 5 * time.Minute
+)
+
+// tokenExpiry returns the point in time at which token expires. The expiry
+// reported by the token source takes precedence over the exp claim of the
+// token, and defaultTokenLifetime is used when neither is available.
+func tokenExpiry(token *Token, issuedAt time.Time) time.Time {
+	if !token.ExpiresAt.IsZero() {
+		return token.ExpiresAt
+	}
+
+	if exp, ok := jwtExpiry(token.AccessToken); ok {
+		return exp
+	}
+
+	return issuedAt.Add(defaultTokenLifetime)
+}
+
+// parseExpiresIn converts the expires_in field of a token response, a number
+// of seconds sent either as a JSON number or as a string, to a duration.
+func parseExpiresIn(value interface{}) (time.Duration, bool) {
+	var seconds float64
+	switch v := value.(type) {
+	case float64:
+		seconds = v
+	case string:
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, false
+		}
+		seconds = parsed
+	default:
+		return 0, false
+	}
+
+	if seconds <= 0 {
+		return 0, false
+	}
+	return time.Duration(seconds * float64(time.Second)), true
+}
+
+// jwtExpiry returns the exp claim of token when token is a JWT. The signature
+// is not verified, the claim is only used to schedule the next refresh.
+func jwtExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(
+		strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		Exp json.Number `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, false
+	}
+
+	exp, err := claims.Exp.Float64()
+	if err != nil || exp <= 0 {
+		return time.Time{}, false
+	}
+
+	sec := int64(exp)
+	nsec := int64((exp - float64(sec)) * float64(time.Second))
+	return time.Unix(sec, nsec), true
+}
+
+// refreshAt returns the point in time at which the token should be replaced.
+// The refresh margin never exceeds half of the token lifetime so that short
+// lived tokens are still used for a while before being refreshed.
+func (t apiToken) refreshAt(margin time.Duration) time.Time {
+	if margin <= 0 {
+		margin = DefaultTokenRefreshMargin
+	}
+
+	if lifetime := t.ExpiresAt.Sub(t.Created); margin > lifetime/2 {
+		margin = lifetime / 2
+	}
+
+	return t.ExpiresAt.Add(-margin)
+}
+
+// TokenExpiry returns the point in time at which the API token currently
+// used by the client expires. The zero time is returned when no token has
+// been issued yet.
+func (c *Credentials) TokenExpiry() time.Time {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.token.Token == "" {
+		return time.Time{}
+	}
+	return c.token.ExpiresAt
+}
@@ -0,0 +1,260 @@
+package rubrikpolaris
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// DefaultTokenCacheDir is the directory, next to
+	// DefaultServiceAccountFile, where service account tokens are cached when
+	// the token cache is enabled.
+	DefaultTokenCacheDir = "~/.rubrik/token-cache"
+
+	// tokenCacheLockRetry is how often an already locked cache file is
+	// checked.
+	tokenCacheLockRetry = 50 * time.Millisecond
+
+	// tokenCacheStaleLock is the age after which a lock file left behind by a
+	// crashed process is removed.
+	tokenCacheStaleLock = time.Minute
+)
+
+// fileTokenCache is a TokenSource which caches the tokens of the wrapped
+// service account token source on disk, so that they can be reused across
+// process runs until they expire. Cache files are encrypted with AES-GCM
+// using a key derived from the client secret, so only holders of the service
+// account credentials can read them.
+type fileTokenCache struct {
+	source TokenSource
+	path   string
+	key    []byte
+	aad    []byte
+	margin time.Duration
+}
+
+// cachedToken is the plaintext content of a token cache file. CreatedAt is
+// zero in files written before it was added.
+type cachedToken struct {
+	AccessToken string    `json:"access_token"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// newFileTokenCache returns a fileTokenCache storing the tokens of the
+// service account identified by clientID and host in dir.
+func newFileTokenCache(source TokenSource, dir, clientID, clientSecret,
+	host string, margin time.Duration) (*fileTokenCache, error) {
+
+	dir, err := ExpandTildePath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	id := sha256.Sum256([]byte(clientID + "\x00" + host))
+
+	mac := hmac.New(sha256.New, []byte(clientSecret))
+	mac.Write([]byte("rubrik-polaris-token-cache\x00"))
+	mac.Write(id[:])
+
+	if margin <= 0 {
+		margin = DefaultTokenRefreshMargin
+	}
+
+	return &fileTokenCache{
+		source: source,
+		path:   filepath.Join(dir, hex.EncodeToString(id[:])),
+		key:    mac.Sum(nil),
+		aad:    id[:],
+		margin: margin,
+	}, nil
+}
+
+// Token returns the cached token when it is still valid, otherwise a new
+// token is requested from the wrapped source and stored in the cache. The
+// cache file is locked while this happens so that concurrent processes
+// share a single token request.
+func (t *fileTokenCache) Token(ctx context.Context) (*Token, error) {
+	if err := os.MkdirAll(filepath.Dir(t.path), 0700); err != nil {
+		return t.source.Token(ctx)
+	}
+
+	unlock, err := t.lock(ctx)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return t.source.Token(ctx)
+	}
+	defer unlock()
+
+	// The cached token is reused until it is due for a refresh, using the
+	// rule of the in-memory token
+	if cached, err := t.read(); err == nil {
+		token := apiToken{Created: cached.CreatedAt, ExpiresAt: cached.ExpiresAt}
+		if time.Now().Before(token.refreshAt(t.margin)) {
+			return &Token{
+				AccessToken: cached.AccessToken,
+				ExpiresAt:   cached.ExpiresAt,
+			}, nil
+		}
+	}
+
+	token, err := t.source.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Failing to write the cache only costs a token request on the next
+	// run, so the error is not reported.
+	now := time.Now()
+	cached := &cachedToken{
+		AccessToken: token.AccessToken,
+		CreatedAt:   now,
+		ExpiresAt:   tokenExpiry(token, now),
+	}
+	_ = t.write(cached)
+
+	return &Token{
+		AccessToken: cached.AccessToken,
+		ExpiresAt:   cached.ExpiresAt,
+	}, nil
+}
+
+// invalidate removes the cache file when it holds token, a token rejected by
+// the API, so that the next call to Token requests a new one.
+func (t *fileTokenCache) invalidate(ctx context.Context, token string) {
+	unlock, err := t.lock(ctx)
+	if err != nil {
+		return
+	}
+	defer unlock()
+
+	if cached, err := t.read(); err == nil && cached.AccessToken == token {
+		os.Remove(t.path)
+	}
+}
+
+// read decrypts the cache file.
+func (t *fileTokenCache) read() (*cachedToken, error) {
+	buf, err := ioutil.ReadFile(t.path)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := t.cipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) < gcm.NonceSize() {
+		return nil, errors.New("truncated token cache file")
+	}
+	plaintext, err := gcm.Open(nil, buf[:gcm.NonceSize()],
+		buf[gcm.NonceSize():], t.aad)
+	if err != nil {
+		return nil, err
+	}
+
+	var cached cachedToken
+	if err := json.Unmarshal(plaintext, &cached); err != nil {
+		return nil, err
+	}
+	if cached.AccessToken == "" {
+		return nil, errors.New("empty token in the token cache file")
+	}
+
+	return &cached, nil
+}
+
+// write encrypts token to the cache file. The file is written to a
+// temporary file first and renamed so that readers never see a partially
+// written cache.
+func (t *fileTokenCache) write(token *cachedToken) error {
+	plaintext, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	gcm, err := t.cipher()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(t.path),
+		filepath.Base(t.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(gcm.Seal(nonce, nonce, plaintext, t.aad)); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), t.path)
+}
+
+// cipher returns the AES-GCM cipher used to encrypt the cache file.
+func (t *fileTokenCache) cipher() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(t.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// lock acquires an exclusive lock on the cache file by creating a lock file
+// next to it. Lock files older than tokenCacheStaleLock are considered left
+// behind by a crashed process and are removed.
+func (t *fileTokenCache) lock(ctx context.Context) (func(), error) {
+	lockPath := t.path + ".lock"
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY,
+			0600)
+		if err == nil {
+			fmt.Fprintf(f, "%d\n", os.Getpid())
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		if info, err := os.Stat(lockPath); err == nil &&
+			time.Since(info.ModTime()) > tokenCacheStaleLock {
+			os.Remove(lockPath)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(tokenCacheLockRetry):
+		}
+	}
+}
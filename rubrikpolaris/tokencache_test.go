@@ -0,0 +1,135 @@
+package rubrikpolaris
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestTokenCacheRejectedToken checks that a cached token rejected by the API
+// is removed from the cache, so that a new token is requested and the
+// request replayed, in this run and in the next ones.
+func TestTokenCacheRejectedToken(t *testing.T) {
+	var mu sync.Mutex
+	var tokenCalls, graphQLCalls int
+	revoked := map[string]bool{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.Path {
+		case "/api/client_token":
+			tokenCalls++
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": fmt.Sprintf("token-%d", tokenCalls),
+				"expires_in":   3600,
+			})
+		case "/api/graphql":
+			graphQLCalls++
+			token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if revoked[token] {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"data":{"ok":true}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	newClient := func() *Credentials {
+		return &Credentials{
+			Host:           strings.TrimPrefix(server.URL, "http://"),
+			BaseURL:        server.URL,
+			ClientId:       "client-id",
+			ClientSecret:   "client-secret",
+			AccessTokenUri: server.URL + "/api/client_token",
+			TokenCacheDir:  dir,
+		}
+	}
+	query := func(c *Credentials) error {
+		_, err := c.Query("query Test { ok }")
+		return err
+	}
+
+	// The first run caches token-1
+	if err := query(newClient()); err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+	if tokenCalls != 1 {
+		t.Fatalf("first run: got %d token calls, want 1", tokenCalls)
+	}
+
+	// The next run reads the revoked token-1 from the cache, is rejected,
+	// requests token-2 and replays the request
+	mu.Lock()
+	revoked["token-1"] = true
+	mu.Unlock()
+	if err := query(newClient()); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if tokenCalls != 2 || graphQLCalls != 3 {
+		t.Fatalf("second run: got %d token calls and %d GraphQL calls, want 2 and 3",
+			tokenCalls, graphQLCalls)
+	}
+
+	// The run after that reuses token-2 from the cache
+	if err := query(newClient()); err != nil {
+		t.Fatalf("third run: %v", err)
+	}
+	if tokenCalls != 2 || graphQLCalls != 4 {
+		t.Fatalf("third run: got %d token calls and %d GraphQL calls, want 2 and 4",
+			tokenCalls, graphQLCalls)
+	}
+}
+
+// TestTokenCacheShortLivedToken checks that tokens living less than twice the
+// refresh margin are reused from the cache, like the in-memory token is.
+func TestTokenCacheShortLivedToken(t *testing.T) {
+	var mu sync.Mutex
+	var tokenCalls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.Path {
+		case "/api/client_token":
+			tokenCalls++
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": fmt.Sprintf("token-%d", tokenCalls),
+				"expires_in":   240,
+			})
+		case "/api/graphql":
+			w.Write([]byte(`{"data":{"ok":true}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	for run := 1; run <= 3; run++ {
+		c := &Credentials{
+			Host:           strings.TrimPrefix(server.URL, "http://"),
+			BaseURL:        server.URL,
+			ClientId:       "client-id",
+			ClientSecret:   "client-secret",
+			AccessTokenUri: server.URL + "/api/client_token",
+			TokenCacheDir:  dir,
+		}
+		if _, err := c.Query("query Test { ok }"); err != nil {
+			t.Fatalf("run %d: %v", run, err)
+		}
+	}
+	if tokenCalls != 1 {
+		t.Fatalf("got %d token calls, want 1", tokenCalls)
+	}
+}
@@ -0,0 +1,131 @@
+package rubrikpolaris
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Token is an API token issued by a TokenSource.
+type Token struct {
+	// AccessToken is the bearer token sent with every GraphQL request.
+	AccessToken string
+
+	// ExpiresAt is the point in time at which the token expires. When it is
+	// zero, the exp claim of the token is used if the token is a JWT,
+	// otherwise the token is assumed to be valid for 24 hours.
+	ExpiresAt time.Time
+}
+
+// TokenSource supplies the API tokens used by a client. Token is called
+// whenever the client has no token yet, when the current token is about to
+// expire and when the API rejects the current token. Calls are serialized by
+// the client, so an implementation does not have to be safe for concurrent
+// use by a single client.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenSourceFunc adapts a function to the TokenSource interface. It can be
+// used to obtain tokens from a central token broker.
+type TokenSourceFunc func(ctx context.Context) (*Token, error)
+
+// Token calls f(ctx).
+func (f TokenSourceFunc) Token(ctx context.Context) (*Token, error) {
+	return f(ctx)
+}
+
+// NewStaticTokenSource returns a TokenSource which always returns the given
+// pre-issued token. expiresAt can be the zero time when the expiry of the
+// token is unknown.
+func NewStaticTokenSource(accessToken string,
+	expiresAt time.Time) TokenSource {
+
+	return TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		if accessToken == "" {
+			return nil, errors.New("the static access token is empty")
+		}
+		return &Token{AccessToken: accessToken, ExpiresAt: expiresAt}, nil
+	})
+}
+
+// NewPasswordTokenSource returns a TokenSource which obtains tokens from the
+// /api/session endpoint using the Username and Password of the client.
+func NewPasswordTokenSource(c *Credentials) TokenSource {
+	return TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		config := map[string]interface{}{}
+		config["username"] = c.Username
+		config["password"] = c.Password
+
+		return c.requestToken(ctx, CallTypeSession, config)
+	})
+}
+
+// NewServiceAccountTokenSource returns a TokenSource which obtains tokens
+// through a client_credentials grant against the AccessTokenUri of the
+// client, using its ClientId and ClientSecret.
+func NewServiceAccountTokenSource(c *Credentials) TokenSource {
+	return TokenSourceFunc(func(ctx context.Context) (*Token, error) {
+		config := map[string]interface{}{}
+		config["grant_type"] = "client_credentials"
+		config["client_id"] = c.ClientId
+		config["client_secret"] = c.ClientSecret
+
+		return c.requestToken(ctx, CallTypeServiceAccount, config)
+	})
+}
+
+// tokenInvalidator is implemented by the token sources which cache tokens.
+// invalidate is called with the token rejected by the API so that it is not
+// returned again.
+type tokenInvalidator interface {
+	invalidate(ctx context.Context, token string)
+}
+
+// tokenSource returns the TokenSource of the client. When none has been set
+// the token source is picked from the populated credentials, and service
+// account tokens go through the on-disk token cache when it is enabled.
+func (c *Credentials) tokenSource() (TokenSource, error) {
+	switch {
+	case c.TokenSource != nil:
+		return c.TokenSource, nil
+	case c.AccessTokenUri != "" && c.TokenCacheDir != "":
+		return newFileTokenCache(NewServiceAccountTokenSource(c),
+			c.TokenCacheDir, c.ClientId, c.ClientSecret, c.Host,
+			c.TokenRefreshMargin)
+	case c.AccessTokenUri != "":
+		return NewServiceAccountTokenSource(c), nil
+	default:
+		return NewPasswordTokenSource(c), nil
+	}
+}
+
+// requestToken sends a token request to the API and returns the issued
+// token.
+func (c *Credentials) requestToken(
+	ctx context.Context,
+	callType string,
+	config map[string]interface{}) (*Token, error) {
+
+	apiRequest, err := c.commonAPI(ctx, callType, config, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	response, ok := apiRequest.(map[string]interface{})
+	if !ok {
+		return nil, &DecodeError{Err: errors.New(
+			"unexpected response to the token request")}
+	}
+	accessToken, ok := response["access_token"].(string)
+	if !ok || accessToken == "" {
+		return nil, &AuthError{Err: errors.New(
+			"the token response does not contain an access_token")}
+	}
+
+	token := &Token{AccessToken: accessToken}
+	if expiresIn, ok := parseExpiresIn(response["expires_in"]); ok {
+		token.ExpiresAt = time.Now().Add(expiresIn)
+	}
+	return token, nil
+}
@@ -0,0 +1,94 @@
+package rubrikpolaris
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the spans of the SDK.
+const tracerName = "github.com/rubrikinc/rubrik-polaris-sdk-for-go-deprecated/rubrikpolaris"
+
+// Span attribute keys.
+const (
+	attrOperationName = attribute.Key("rubrik.graphql.operation.name")
+	attrCallType      = attribute.Key("rubrik.call_type")
+	attrPage          = attribute.Key("rubrik.page")
+	attrPages         = attribute.Key("rubrik.pages")
+	attrItems         = attribute.Key("rubrik.items")
+	attrStatusCode    = attribute.Key("http.response.status_code")
+)
+
+// pageKey is the context key holding the page number of a paginated request.
+type pageKey struct{}
+
+// withPage returns a copy of ctx recording that the requests made with it
+// fetch the given page of a paginated query.
+func withPage(ctx context.Context, page int) context.Context {
+	return context.WithValue(ctx, pageKey{}, page)
+}
+
+// tracer returns the tracer of the client, obtained from TracerProvider or
+// from the global OpenTelemetry tracer provider when it is nil.
+func (c *Credentials) tracer() trace.Tracer {
+	provider := c.TracerProvider
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return provider.Tracer(tracerName)
+}
+
+// propagator returns the propagator injecting the trace context into the
+// HTTP headers of the requests, Propagator or the global OpenTelemetry
+// propagator when it is nil.
+func (c *Credentials) propagator() propagation.TextMapPropagator {
+	if c.Propagator != nil {
+		return c.Propagator
+	}
+	return otel.GetTextMapPropagator()
+}
+
+// startSpan starts a span named after the client method, e.g.
+// "rubrikpolaris.GetCDMClusterIdByName".
+func (c *Credentials) startSpan(
+	ctx context.Context,
+	method string,
+	attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+
+	return c.tracer().Start(ctx, "rubrikpolaris."+method,
+		trace.WithAttributes(attrs...))
+}
+
+// startRequestSpan starts the client span of a GraphQL request, named after
+// its operation name.
+func (c *Credentials) startRequestSpan(
+	ctx context.Context,
+	operationName string) (context.Context, trace.Span) {
+
+	attrs := []attribute.KeyValue{attrOperationName.String(operationName)}
+	if page, ok := ctx.Value(pageKey{}).(int); ok {
+		attrs = append(attrs, attrPage.Int(page))
+	}
+	return c.tracer().Start(ctx, operationName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+}
+
+// injectTraceContext adds the trace context of ctx to the HTTP headers.
+func (c *Credentials) injectTraceContext(ctx context.Context, header http.Header) {
+	c.propagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// endSpan records err, if any, on the span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
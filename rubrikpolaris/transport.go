@@ -0,0 +1,205 @@
+package rubrikpolaris
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// Defaults of the connection pool settings of Credentials.
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 10
+	DefaultIdleConnTimeout     = 90 * time.Second
+	DefaultKeepAlive           = 30 * time.Second
+
+	// dialTimeout is the maximum time spent establishing a connection.
+	dialTimeout = 30 * time.Second
+
+	// tlsHandshakeTimeout is the maximum time spent on a TLS handshake.
+	tlsHandshakeTimeout = 10 * time.Second
+)
+
+// client returns the long-lived HTTP client used for all requests of the
+// client. It is built on first use so that the connections it pools are
+// reused by every subsequent request.
+func (c *Credentials) client() (*http.Client, error) {
+	c.httpClientOnce.Do(func() {
+		switch {
+		case c.HTTPClient != nil:
+			c.httpClient = c.HTTPClient
+		case c.Transport != nil:
+			c.httpClient = &http.Client{Transport: c.Transport}
+		default:
+			var tr *http.Transport
+			tr, c.httpClientErr = c.newTransport()
+			c.httpClient = &http.Client{Transport: tr}
+		}
+	})
+
+	return c.httpClient, c.httpClientErr
+}
+
+// newTransport returns a new HTTP transport configured with the TLS and
+// connection pool settings of the client.
+func (c *Credentials) newTransport() (*http.Transport, error) {
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := c.proxy()
+	if err != nil {
+		return nil, err
+	}
+
+	dialContext := c.DialContext
+	if dialContext == nil {
+		keepAlive := c.KeepAlive
+		if keepAlive == 0 {
+			keepAlive = DefaultKeepAlive
+		}
+		dialer := &net.Dialer{
+			Timeout:   dialTimeout,
+			KeepAlive: keepAlive,
+		}
+		dialContext = dialer.DialContext
+	}
+
+	tr := &http.Transport{
+		Proxy:               proxy,
+		DialContext:         dialContext,
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: tlsHandshakeTimeout,
+		ForceAttemptHTTP2:   true,
+		MaxIdleConns:        c.MaxIdleConns,
+		MaxIdleConnsPerHost: c.MaxIdleConnsPerHost,
+		MaxConnsPerHost:     c.MaxConnsPerHost,
+		IdleConnTimeout:     c.IdleConnTimeout,
+	}
+	if tr.MaxIdleConns == 0 {
+		tr.MaxIdleConns = DefaultMaxIdleConns
+	}
+	if tr.MaxIdleConnsPerHost == 0 {
+		tr.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+	if tr.IdleConnTimeout == 0 {
+		tr.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+
+	return tr, nil
+}
+
+// proxy returns the function selecting the proxy of each request. Proxy takes
+// precedence over ProxyURL, and the standard HTTPS_PROXY, HTTP_PROXY and
+// NO_PROXY environment variables are used when neither is set.
+func (c *Credentials) proxy() (func(*http.Request) (*url.URL, error), error) {
+	switch {
+	case c.Proxy != nil:
+		return c.Proxy, nil
+
+	case c.ProxyURL != "":
+		proxyURL, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			// The *url.Error holds the whole URL, including its password
+			return nil, fmt.Errorf("invalid proxy URL: %v", errors.Unwrap(err))
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf(
+				"invalid proxy URL: unsupported scheme %q", proxyURL.Scheme)
+		}
+		if proxyURL.Host == "" {
+			return nil, errors.New("invalid proxy URL: missing host")
+		}
+		return http.ProxyURL(proxyURL), nil
+
+	default:
+		return http.ProxyFromEnvironment, nil
+	}
+}
+
+// CloseIdleConnections closes the idle connections kept open by the client.
+// It does not interrupt requests in flight.
+func (c *Credentials) CloseIdleConnections() {
+	if client, err := c.client(); err == nil && client != nil {
+		client.CloseIdleConnections()
+	}
+}
+
+// tlsConfig returns the TLS configuration used for all requests of the
+// client. Server certificates are verified unless
+// DangerouslySkipTLSVerification is set.
+func (c *Credentials) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    c.RootCAs,
+	}
+
+	if c.DangerouslySkipTLSVerification {
+		config.InsecureSkipVerify = true
+	}
+
+	if c.CACertFile != "" {
+		pool := c.RootCAs
+		if pool == nil {
+			systemPool, err := x509.SystemCertPool()
+			if err != nil || systemPool == nil {
+				systemPool = x509.NewCertPool()
+			}
+			pool = systemPool
+		} else {
+			pool = pool.Clone()
+		}
+
+		caCertFile, err := ExpandTildePath(c.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		pem, err := ioutil.ReadFile(caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the CA bundle: %v", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf(
+				"no PEM certificates found in the CA bundle '%s'",
+				c.CACertFile)
+		}
+		config.RootCAs = pool
+	}
+
+	switch {
+	case c.ClientCertificate != nil:
+		config.Certificates = []tls.Certificate{*c.ClientCertificate}
+
+	case c.ClientCertFile != "" || c.ClientKeyFile != "":
+		if c.ClientCertFile == "" || c.ClientKeyFile == "" {
+			return nil, errors.New(
+				"both ClientCertFile and ClientKeyFile must be set for " +
+					"mutual TLS")
+		}
+		certFile, err := ExpandTildePath(c.ClientCertFile)
+		if err != nil {
+			return nil, err
+		}
+		keyFile, err := ExpandTildePath(c.ClientKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to load the client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
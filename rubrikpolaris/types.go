@@ -1,5 +1,13 @@
 package rubrikpolaris
 
+import "encoding/json"
+
+// The response types of the Get* methods keep the shapes of the first
+// releases of the SDK. They are filled from the generated responses of
+// operations_gen.go by convertResponse.
+
+// RadarEnabledClusters is the response of the RadarEnabledClusters.graphql
+// query.
 type RadarEnabledClusters struct {
 	Data struct {
 		RadarClusterConnection struct {
@@ -12,6 +20,8 @@ type RadarEnabledClusters struct {
 	} `mapstructure:"data"`
 }
 
+// AllEvent is the response of the AllEventsPerTimePeriod.graphql query,
+// without the __typename fields.
 type AllEvent struct {
 	Data struct {
 		ActivitySeriesConnection struct {
@@ -51,6 +61,7 @@ type AllEvent struct {
 	} `mapstructure:"data"`
 }
 
+// AllAuditLog is the response of GetAllAuditLog.
 type AllAuditLog struct {
 	Data struct {
 		UserAuditConnection struct {
@@ -71,6 +82,7 @@ type AllAuditLog struct {
 	} `mapstructure:"data"`
 }
 
+// AllEvents is the response of GetAllEvents.
 type AllEvents struct {
 	Data struct {
 		ActivitySeriesConnection struct {
@@ -117,6 +129,7 @@ type AllEvents struct {
 	} `mapstructure:"data"`
 }
 
+// EventSeriesDetail is the response of GetEventDetails.
 type EventSeriesDetail struct {
 	Data struct {
 		ActivitySeries struct {
@@ -143,6 +156,8 @@ type EventSeriesDetail struct {
 	} `mapstructure:"data"`
 }
 
+// EventSeriesDetailMessage is an activity of an EventSeriesDetail flattened
+// together with the fields of its event series.
 type EventSeriesDetailMessage struct {
 	Message          string `mapstructure:"message"`
 	Status           string `mapstructure:"status"`
@@ -160,6 +175,8 @@ type EventSeriesDetailMessage struct {
 	} `mapstructure:"cluster"`
 }
 
+// RadarEvent is the response of GetRadarEvents and GetRadarAndSonarEvents,
+// whose queries select the same fields.
 type RadarEvent struct {
 	Data struct {
 		ActivitySeriesConnection struct {
@@ -193,6 +210,8 @@ type RadarEvent struct {
 	} `mapstructure:"data"`
 }
 
+// PolarisEvents is the response of GetAllPolarisEvents and
+// GetAllRscEventsForCluster.
 type PolarisEvents struct {
 	Data struct {
 		ActivitySeriesConnection struct {
@@ -231,6 +250,7 @@ type PolarisEvents struct {
 	} `mapstructure:"data"`
 }
 
+// PolarisEventsEdge is an edge of PolarisEvents.
 type PolarisEventsEdge struct {
 	Node struct {
 		ID                 int    `mapstructure:"id"`
@@ -258,6 +278,7 @@ type PolarisEventsEdge struct {
 	} `mapstructure:"node"`
 }
 
+// ClusterIdByName is the response of the CDMClusterIdByName.graphql query.
 type ClusterIdByName struct {
 	Data struct {
 		ClusterConnection struct {
@@ -275,6 +296,7 @@ type ClusterIdByName struct {
 	} `mapstructure:"data"`
 }
 
+// EnableRadar is the response of EnableRadar.
 type EnableRadar struct {
 	Data struct {
 		EnableAutomaticFmdUpload struct {
@@ -283,3 +305,21 @@ type EnableRadar struct {
 		} `mapstructure:"enableAutomaticFmdUpload"`
 	} `mapstructure:"data"`
 }
+
+// convertResponse copies in, a generated response, into out, a pointer to the
+// Data field of one of the response types above. The fields are matched by
+// name through encoding/json, which ignores the case of the names, as the
+// types above only carry mapstructure tags.
+func convertResponse(in, out interface{}) error {
+	buf, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(buf, out); err != nil {
+		return &DecodeError{
+			Type: typeName(out),
+			Err:  err,
+		}
+	}
+	return nil
+}
@@ -1,10 +1,12 @@
 package rubrikpolaris
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 const (
@@ -53,3 +55,26 @@ func OperationNamePrefix(operationNameSecondPrefix ...string) string {
 	}
 	return op
 }
+
+// sleepContext pauses for the duration d or until ctx is done, whichever
+// happens first. The context error is returned in the latter case.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// stringValue returns the string pointed to by s, or an empty string when s
+// is nil.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
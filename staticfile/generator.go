@@ -104,4 +104,9 @@ func main() {
 	if err = ioutil.WriteFile(blobFileName, data, os.ModePerm); err != nil {
 		log.Fatal("Error writing blob file", err)
 	}
-}
\ No newline at end of file
+
+	// Generating the typed operations
+	if err = generateOperations(configs); err != nil {
+		log.Fatal("Error generating operations: ", err)
+	}
+}
@@ -10,6 +10,7 @@ func init() {
 	box.Add("query/EnableRadar.graphql", []byte{109, 117, 116, 97, 116, 105, 111, 110, 32, 84, 111, 103, 103, 108, 101, 82, 97, 100, 97, 114, 80, 114, 101, 102, 115, 77, 117, 116, 97, 116, 105, 111, 110, 40, 36, 99, 108, 117, 115, 116, 101, 114, 73, 100, 58, 32, 85, 85, 73, 68, 33, 41, 32, 123, 10, 9, 101, 110, 97, 98, 108, 101, 65, 117, 116, 111, 109, 97, 116, 105, 99, 70, 109, 100, 85, 112, 108, 111, 97, 100, 40, 99, 108, 117, 115, 116, 101, 114, 85, 117, 105, 100, 58, 32, 36, 99, 108, 117, 115, 116, 101, 114, 73, 100, 44, 32, 101, 110, 97, 98, 108, 101, 100, 58, 32, 116, 114, 117, 101, 41, 32, 123, 10, 9, 9, 99, 108, 117, 115, 116, 101, 114, 73, 100, 10, 9, 9, 101, 110, 97, 98, 108, 101, 100, 10, 9, 125, 10, 125, 10})
 	box.Add("query/EventDetails.graphql", []byte{113, 117, 101, 114, 121, 32, 69, 118, 101, 110, 116, 68, 101, 116, 97, 105, 108, 115, 40, 36, 97, 99, 116, 105, 118, 105, 116, 121, 83, 101, 114, 105, 101, 115, 73, 100, 58, 32, 85, 85, 73, 68, 33, 44, 32, 36, 99, 108, 117, 115, 116, 101, 114, 85, 117, 105, 100, 58, 32, 85, 85, 73, 68, 33, 41, 32, 123, 10, 9, 97, 99, 116, 105, 118, 105, 116, 121, 83, 101, 114, 105, 101, 115, 40, 105, 110, 112, 117, 116, 58, 32, 123, 97, 99, 116, 105, 118, 105, 116, 121, 83, 101, 114, 105, 101, 115, 73, 100, 58, 32, 36, 97, 99, 116, 105, 118, 105, 116, 121, 83, 101, 114, 105, 101, 115, 73, 100, 44, 32, 99, 108, 117, 115, 116, 101, 114, 85, 117, 105, 100, 58, 32, 36, 99, 108, 117, 115, 116, 101, 114, 85, 117, 105, 100, 125, 41, 32, 123, 10, 9, 9, 97, 99, 116, 105, 118, 105, 116, 121, 67, 111, 110, 110, 101, 99, 116, 105, 111, 110, 32, 123, 10, 9, 9, 9, 110, 111, 100, 101, 115, 32, 123, 10, 9, 9, 9, 9, 109, 101, 115, 115, 97, 103, 101, 10, 9, 9, 9, 9, 115, 116, 97, 116, 117, 115, 10, 9, 9, 9, 9, 116, 105, 109, 101, 10, 9, 9, 9, 9, 115, 101, 118, 101, 114, 105, 116, 121, 10, 9, 9, 9, 125, 10, 9, 9, 125, 10, 9, 9, 105, 100, 10, 9, 9, 102, 105, 100, 10, 9, 9, 97, 99, 116, 105, 118, 105, 116, 121, 83, 101, 114, 105, 101, 115, 73, 100, 10, 9, 9, 111, 98, 106, 101, 99, 116, 73, 100, 10, 9, 9, 111, 98, 106, 101, 99, 116, 78, 97, 109, 101, 10, 9, 9, 111, 98, 106, 101, 99, 116, 84, 121, 112, 101, 10, 9, 9, 99, 108, 117, 115, 116, 101, 114, 32, 123, 10, 9, 9, 9, 105, 100, 10, 9, 9, 9, 110, 97, 109, 101, 10, 9, 9, 125, 10, 9, 9, 108, 97, 115, 116, 65, 99, 116, 105, 118, 105, 116, 121, 83, 116, 97, 116, 117, 115, 10, 9, 125, 10, 125, 10})
 	box.Add("query/RadarEnabledClusters.graphql", []byte{113, 117, 101, 114, 121, 32, 82, 97, 100, 97, 114, 69, 110, 97, 98, 108, 101, 100, 67, 108, 117, 115, 116, 101, 114, 115, 32, 123, 10, 9, 114, 97, 100, 97, 114, 67, 108, 117, 115, 116, 101, 114, 67, 111, 110, 110, 101, 99, 116, 105, 111, 110, 32, 123, 10, 9, 9, 110, 111, 100, 101, 115, 32, 123, 10, 9, 9, 9, 108, 97, 109, 98, 100, 97, 67, 111, 110, 102, 105, 103, 32, 123, 10, 9, 9, 9, 9, 99, 108, 117, 115, 116, 101, 114, 73, 100, 10, 9, 9, 9, 9, 101, 110, 97, 98, 108, 101, 65, 117, 116, 111, 109, 97, 116, 105, 99, 70, 109, 100, 85, 112, 108, 111, 97, 100, 10, 9, 9, 9, 125, 10, 9, 9, 9, 110, 97, 109, 101, 10, 9, 9, 125, 10, 9, 125, 10, 125, 10})
+	box.Add("query/RadarEventCount.graphql", []byte{113, 117, 101, 114, 121, 32, 82, 97, 100, 97, 114, 69, 118, 101, 110, 116, 67, 111, 117, 110, 116, 40, 36, 115, 105, 110, 99, 101, 58, 32, 68, 97, 116, 101, 84, 105, 109, 101, 44, 32, 36, 117, 110, 116, 105, 108, 58, 32, 68, 97, 116, 101, 84, 105, 109, 101, 41, 32, 123, 10, 32, 32, 97, 99, 116, 105, 118, 105, 116, 121, 83, 101, 114, 105, 101, 115, 67, 111, 110, 110, 101, 99, 116, 105, 111, 110, 40, 10, 32, 32, 32, 32, 102, 105, 108, 116, 101, 114, 115, 58, 32, 123, 10, 32, 32, 32, 32, 32, 32, 108, 97, 115, 116, 65, 99, 116, 105, 118, 105, 116, 121, 84, 121, 112, 101, 58, 32, 91, 65, 78, 79, 77, 65, 76, 89, 93, 10, 32, 32, 32, 32, 32, 32, 108, 97, 115, 116, 85, 112, 100, 97, 116, 101, 100, 84, 105, 109, 101, 71, 116, 58, 32, 36, 115, 105, 110, 99, 101, 10, 32, 32, 32, 32, 32, 32, 108, 97, 115, 116, 85, 112, 100, 97, 116, 101, 100, 84, 105, 109, 101, 76, 116, 58, 32, 36, 117, 110, 116, 105, 108, 10, 32, 32, 32, 32, 125, 10, 32, 32, 41, 32, 123, 10, 32, 32, 32, 32, 99, 111, 117, 110, 116, 10, 32, 32, 125, 10, 125, 10})
 	box.Add("query/RadarEventsPerTimePeriod.graphql", []byte{113, 117, 101, 114, 121, 32, 82, 97, 100, 97, 114, 69, 118, 101, 110, 116, 115, 80, 101, 114, 84, 105, 109, 101, 80, 101, 114, 105, 111, 100, 40, 36, 116, 105, 109, 101, 65, 103, 111, 58, 32, 68, 97, 116, 101, 84, 105, 109, 101, 41, 32, 123, 10, 32, 32, 97, 99, 116, 105, 118, 105, 116, 121, 83, 101, 114, 105, 101, 115, 67, 111, 110, 110, 101, 99, 116, 105, 111, 110, 40, 10, 32, 32, 32, 32, 102, 105, 108, 116, 101, 114, 115, 58, 32, 123, 32, 108, 97, 115, 116, 65, 99, 116, 105, 118, 105, 116, 121, 84, 121, 112, 101, 58, 32, 91, 65, 78, 79, 77, 65, 76, 89, 93, 44, 32, 108, 97, 115, 116, 85, 112, 100, 97, 116, 101, 100, 84, 105, 109, 101, 71, 116, 58, 32, 36, 116, 105, 109, 101, 65, 103, 111, 32, 125, 10, 32, 32, 32, 32, 102, 105, 114, 115, 116, 58, 32, 49, 48, 48, 48, 10, 32, 32, 41, 32, 123, 10, 32, 32, 32, 32, 101, 100, 103, 101, 115, 32, 123, 10, 32, 32, 32, 32, 32, 32, 110, 111, 100, 101, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 105, 100, 10, 32, 32, 32, 32, 32, 32, 32, 32, 102, 105, 100, 10, 32, 32, 32, 32, 32, 32, 32, 32, 97, 99, 116, 105, 118, 105, 116, 121, 83, 101, 114, 105, 101, 115, 73, 100, 10, 32, 32, 32, 32, 32, 32, 32, 32, 108, 97, 115, 116, 85, 112, 100, 97, 116, 101, 100, 10, 32, 32, 32, 32, 32, 32, 32, 32, 108, 97, 115, 116, 65, 99, 116, 105, 118, 105, 116, 121, 84, 121, 112, 101, 10, 32, 32, 32, 32, 32, 32, 32, 32, 108, 97, 115, 116, 65, 99, 116, 105, 118, 105, 116, 121, 83, 116, 97, 116, 117, 115, 10, 32, 32, 32, 32, 32, 32, 32, 32, 111, 98, 106, 101, 99, 116, 73, 100, 10, 32, 32, 32, 32, 32, 32, 32, 32, 111, 98, 106, 101, 99, 116, 78, 97, 109, 101, 10, 32, 32, 32, 32, 32, 32, 32, 32, 111, 98, 106, 101, 99, 116, 84, 121, 112, 101, 10, 32, 32, 32, 32, 32, 32, 32, 32, 115, 101, 118, 101, 114, 105, 116, 121, 10, 32, 32, 32, 32, 32, 32, 32, 32, 112, 114, 111, 103, 114, 101, 115, 115, 10, 32, 32, 32, 32, 32, 32, 32, 32, 99, 108, 117, 115, 116, 101, 114, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 105, 100, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 110, 97, 109, 101, 10, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 97, 99, 116, 105, 118, 105, 116, 121, 67, 111, 110, 110, 101, 99, 116, 105, 111, 110, 32, 40, 102, 105, 114, 115, 116, 58, 32, 50, 48, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 110, 111, 100, 101, 115, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 105, 100, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 109, 101, 115, 115, 97, 103, 101, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 116, 105, 109, 101, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 125, 10, 32, 32, 125, 10, 125, 10})
 	box.Add("query/RadarSonarEventsPerTimePeriod.graphql", []byte{113, 117, 101, 114, 121, 32, 82, 97, 100, 97, 114, 83, 111, 110, 97, 114, 69, 118, 101, 110, 116, 115, 80, 101, 114, 84, 105, 109, 101, 80, 101, 114, 105, 111, 100, 40, 36, 116, 105, 109, 101, 65, 103, 111, 58, 32, 68, 97, 116, 101, 84, 105, 109, 101, 41, 32, 123, 10, 32, 32, 97, 99, 116, 105, 118, 105, 116, 121, 83, 101, 114, 105, 101, 115, 67, 111, 110, 110, 101, 99, 116, 105, 111, 110, 40, 10, 32, 32, 32, 32, 102, 105, 108, 116, 101, 114, 115, 58, 32, 123, 10, 32, 32, 32, 32, 32, 32, 108, 97, 115, 116, 65, 99, 116, 105, 118, 105, 116, 121, 84, 121, 112, 101, 58, 32, 91, 65, 78, 79, 77, 65, 76, 89, 44, 32, 67, 76, 65, 83, 83, 73, 70, 73, 67, 65, 84, 73, 79, 78, 93, 10, 32, 32, 32, 32, 32, 32, 108, 97, 115, 116, 85, 112, 100, 97, 116, 101, 100, 84, 105, 109, 101, 71, 116, 58, 32, 36, 116, 105, 109, 101, 65, 103, 111, 10, 32, 32, 32, 32, 125, 10, 32, 32, 41, 32, 123, 10, 32, 32, 32, 32, 101, 100, 103, 101, 115, 32, 123, 10, 32, 32, 32, 32, 32, 32, 110, 111, 100, 101, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 105, 100, 10, 32, 32, 32, 32, 32, 32, 32, 32, 102, 105, 100, 10, 32, 32, 32, 32, 32, 32, 32, 32, 97, 99, 116, 105, 118, 105, 116, 121, 83, 101, 114, 105, 101, 115, 73, 100, 10, 32, 32, 32, 32, 32, 32, 32, 32, 108, 97, 115, 116, 85, 112, 100, 97, 116, 101, 100, 10, 32, 32, 32, 32, 32, 32, 32, 32, 108, 97, 115, 116, 65, 99, 116, 105, 118, 105, 116, 121, 84, 121, 112, 101, 10, 32, 32, 32, 32, 32, 32, 32, 32, 108, 97, 115, 116, 65, 99, 116, 105, 118, 105, 116, 121, 83, 116, 97, 116, 117, 115, 10, 32, 32, 32, 32, 32, 32, 32, 32, 111, 98, 106, 101, 99, 116, 73, 100, 10, 32, 32, 32, 32, 32, 32, 32, 32, 111, 98, 106, 101, 99, 116, 78, 97, 109, 101, 10, 32, 32, 32, 32, 32, 32, 32, 32, 111, 98, 106, 101, 99, 116, 84, 121, 112, 101, 10, 32, 32, 32, 32, 32, 32, 32, 32, 115, 101, 118, 101, 114, 105, 116, 121, 10, 32, 32, 32, 32, 32, 32, 32, 32, 112, 114, 111, 103, 114, 101, 115, 115, 10, 32, 32, 32, 32, 32, 32, 32, 32, 99, 108, 117, 115, 116, 101, 114, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 105, 100, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 110, 97, 109, 101, 10, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 97, 99, 116, 105, 118, 105, 116, 121, 67, 111, 110, 110, 101, 99, 116, 105, 111, 110, 32, 40, 102, 105, 114, 115, 116, 58, 32, 50, 48, 41, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 110, 111, 100, 101, 115, 32, 123, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 105, 100, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 109, 101, 115, 115, 97, 103, 101, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 116, 105, 109, 101, 10, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 32, 32, 125, 10, 32, 32, 32, 32, 125, 10, 32, 32, 125, 10, 125, 10})
 }
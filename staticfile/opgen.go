@@ -0,0 +1,335 @@
+//+build ignore
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const (
+	schemaFileName     string = "schema.graphql"
+	operationsFileName string = "../rubrikpolaris/operations_gen.go"
+)
+
+// scalars maps the GraphQL scalars to Go types, unknown scalars are decoded
+// into interface{}.
+var scalars = map[string]string{
+	"Boolean":  "bool",
+	"DateTime": "string",
+	"Float":    "float64",
+	"ID":       "string",
+	"Int":      "int",
+	"Long":     "int64",
+	"String":   "string",
+	"UUID":     "string",
+}
+
+// initialisms are the words written in upper case in Go names.
+var initialisms = map[string]bool{
+	"api": true, "id": true, "json": true, "http": true, "url": true,
+	"uuid": true,
+}
+
+var opTmpl = template.Must(template.New("").Parse(`package rubrikpolaris
+
+// Code generated by go generate; DO NOT EDIT.
+
+import "context"
+{{ range .Inputs }}
+// {{ .Name }} is the {{ .Name }} GraphQL input type.
+type {{ .Name }} {{ .Type }}
+{{ end }}
+{{- range .Operations }}
+{{- if .Variables }}
+// {{ .Name }}Variables holds the variables of the {{ .Name }} {{ .Kind }}.
+type {{ .Name }}Variables {{ .Variables }}
+{{ end }}
+// {{ .Name }}Response holds the data returned by the {{ .Name }} {{ .Kind }}.
+type {{ .Name }}Response {{ .Response }}
+
+// {{ .Name }} sends the {{ .Name }} {{ .Kind }} of {{ .File }}.
+func (c *Credentials) {{ .Name }}({{ if .Variables }}vars *{{ .Name }}Variables, {{ end }}timeout ...int) (*{{ .Name }}Response, error) {
+	return c.{{ .Name }}Context(context.Background(), {{ if .Variables }}vars, {{ end }}timeout...)
+}
+
+// {{ .Name }}Context is like {{ .Name }} but takes a context which cancels
+// the underlying requests when done.
+func (c *Credentials) {{ .Name }}Context(ctx context.Context, {{ if .Variables }}vars *{{ .Name }}Variables, {{ end }}timeout ...int) (*{{ .Name }}Response, error) {
+	return doOperation[{{ .Name }}Response](ctx, c, "{{ .File }}", {{ if .Variables }}vars{{ else }}nil{{ end }}, {{ .Mutation }}, timeout...)
+}
+{{ end }}`))
+
+// operation is a GraphQL operation of an embedded file.
+type operation struct {
+	File      string
+	Name      string
+	Kind      string
+	Mutation  bool
+	Variables string
+	Response  string
+}
+
+// inputType is a GraphQL input type used by the variables of an operation.
+type inputType struct {
+	Name string
+	Type string
+}
+
+// opGenerator generates the Go types of the operations of a schema.
+type opGenerator struct {
+	schema *ast.Schema
+	inputs map[string]string
+}
+
+// generateOperations validates the GraphQL files against the schema and
+// writes the typed variables, responses and client methods of their
+// operations.
+func generateOperations(files map[string][]byte) error {
+	schemaFile, err := ioutil.ReadFile(schemaFileName)
+	if err != nil {
+		return err
+	}
+	schema, err := gqlparser.LoadSchema(&ast.Source{
+		Name:  schemaFileName,
+		Input: string(schemaFile),
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %v", schemaFileName, err)
+	}
+
+	g := &opGenerator{schema: schema, inputs: map[string]string{}}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var operations []operation
+	for _, name := range names {
+		doc, errs := gqlparser.LoadQuery(schema, string(files[name]))
+		if len(errs) > 0 {
+			return fmt.Errorf("%s: %v", name, errs)
+		}
+
+		for _, op := range doc.Operations {
+			if op.Name == "" {
+				return fmt.Errorf("%s: anonymous operations are not supported", name)
+			}
+			operations = append(operations, g.operation(path.Base(name), op))
+		}
+	}
+
+	inputs := make([]inputType, 0, len(g.inputs))
+	for name, typ := range g.inputs {
+		inputs = append(inputs, inputType{Name: name, Type: typ})
+	}
+	sort.Slice(inputs, func(i, j int) bool { return inputs[i].Name < inputs[j].Name })
+
+	builder := &bytes.Buffer{}
+	err = opTmpl.Execute(builder, map[string]interface{}{
+		"Inputs":     inputs,
+		"Operations": operations,
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := format.Source(builder.Bytes())
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(operationsFileName, data, 0644)
+}
+
+// operation returns the Go types of an operation of the given file.
+func (g *opGenerator) operation(file string, op *ast.OperationDefinition) operation {
+	o := operation{
+		File:     file,
+		Name:     goName(op.Name),
+		Kind:     string(op.Operation),
+		Mutation: op.Operation == ast.Mutation,
+		Response: g.selectionType(op.SelectionSet),
+	}
+
+	if len(op.VariableDefinitions) > 0 {
+		var b strings.Builder
+		b.WriteString("struct {\n")
+		for _, v := range op.VariableDefinitions {
+			fmt.Fprintf(&b, "%s %s `json:\"%s%s\"`\n", goName(v.Variable),
+				g.inputGoType(v.Type), v.Variable, omitEmpty(v.Type))
+		}
+		b.WriteString("}")
+		o.Variables = b.String()
+	}
+
+	return o
+}
+
+// fieldSelection is a response field, with the selection sets of all the
+// selections of the field merged.
+type fieldSelection struct {
+	alias string
+	field *ast.Field
+	set   ast.SelectionSet
+}
+
+// collectFields flattens the fields, fragment spreads and inline fragments
+// of a selection set, merging the fields selected more than once.
+func collectFields(set ast.SelectionSet, fields []*fieldSelection) []*fieldSelection {
+	for _, selection := range set {
+		switch s := selection.(type) {
+		case *ast.Field:
+			merged := false
+			for _, f := range fields {
+				if f.alias == s.Alias {
+					f.set = append(f.set, s.SelectionSet...)
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				fields = append(fields, &fieldSelection{
+					alias: s.Alias,
+					field: s,
+					set:   append(ast.SelectionSet{}, s.SelectionSet...),
+				})
+			}
+		case *ast.FragmentSpread:
+			fields = collectFields(s.Definition.SelectionSet, fields)
+		case *ast.InlineFragment:
+			fields = collectFields(s.SelectionSet, fields)
+		}
+	}
+	return fields
+}
+
+// selectionType returns the Go struct type of a selection set.
+func (g *opGenerator) selectionType(set ast.SelectionSet) string {
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	for _, f := range collectFields(set, nil) {
+		fmt.Fprintf(&b, "%s %s `json:\"%s\"`\n", goName(f.alias),
+			g.outputGoType(f.field.Definition.Type, f.set), f.alias)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// outputGoType returns the Go type of a response field of the given type.
+// Nullable objects and scalars are pointers, so that null can be told apart
+// from a zero value.
+func (g *opGenerator) outputGoType(t *ast.Type, set ast.SelectionSet) string {
+	if t.Elem != nil {
+		return "[]" + g.outputGoType(t.Elem, set)
+	}
+
+	var typ string
+	def := g.schema.Types[t.NamedType]
+	switch def.Kind {
+	case ast.Object, ast.Interface, ast.Union:
+		typ = g.selectionType(set)
+	case ast.Enum:
+		typ = "string"
+	default:
+		typ = scalarGoType(t.NamedType)
+	}
+
+	if !t.NonNull && typ != "interface{}" {
+		return "*" + typ
+	}
+	return typ
+}
+
+// inputGoType returns the Go type of a variable or input field of the given
+// type. Nullable scalars and input objects are pointers.
+func (g *opGenerator) inputGoType(t *ast.Type) string {
+	if t.Elem != nil {
+		return "[]" + g.inputGoType(t.Elem)
+	}
+
+	var typ string
+	def := g.schema.Types[t.NamedType]
+	switch def.Kind {
+	case ast.InputObject:
+		typ = goName(def.Name)
+		if _, ok := g.inputs[typ]; !ok {
+			// Register the type before its fields, which can refer to it
+			g.inputs[typ] = ""
+			var b strings.Builder
+			b.WriteString("struct {\n")
+			for _, f := range def.Fields {
+				fmt.Fprintf(&b, "%s %s `json:\"%s%s\"`\n", goName(f.Name),
+					g.inputGoType(f.Type), f.Name, omitEmpty(f.Type))
+			}
+			b.WriteString("}")
+			g.inputs[typ] = b.String()
+		}
+	case ast.Enum:
+		typ = "string"
+	default:
+		typ = scalarGoType(t.NamedType)
+	}
+
+	if !t.NonNull && typ != "interface{}" {
+		return "*" + typ
+	}
+	return typ
+}
+
+// omitEmpty returns the omitempty JSON option for nullable types.
+func omitEmpty(t *ast.Type) string {
+	if t.NonNull {
+		return ""
+	}
+	return ",omitempty"
+}
+
+// scalarGoType returns the Go type of a GraphQL scalar.
+func scalarGoType(name string) string {
+	if typ, ok := scalars[name]; ok {
+		return typ
+	}
+	return "interface{}"
+}
+
+// goName returns the exported Go name of a GraphQL name, e.g. ID for id and
+// ActivitySeriesID for activitySeriesId.
+func goName(name string) string {
+	name = strings.TrimLeft(name, "_")
+
+	var words []string
+	start := 0
+	for i, r := range name {
+		if i > 0 && (unicode.IsUpper(r) || r == '_') {
+			words = append(words, name[start:i])
+			start = i
+		}
+	}
+	words = append(words, name[start:])
+
+	var b strings.Builder
+	for _, word := range words {
+		word = strings.TrimLeft(word, "_")
+		if word == "" {
+			continue
+		}
+		if initialisms[strings.ToLower(word)] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,12 @@
+//go:build tools
+// +build tools
+
+package staticfile
+
+// The generators are built with the ignore tag, which go mod tidy skips.
+// Importing their dependencies here keeps them, and their go.sum entries, in
+// the module.
+import (
+	_ "github.com/vektah/gqlparser/v2"
+	_ "github.com/vektah/gqlparser/v2/ast"
+)